@@ -0,0 +1,47 @@
+package civogo
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// apiTimeLayouts are the timestamp formats the API has been seen to return, tried in order.
+// Layouts without a zone are taken to be UTC
+var apiTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// apiTime decodes a timestamp from the API leniently: null, an empty string or a timestamp in an
+// unrecognised format decode to the zero time rather than failing the whole response
+type apiTime time.Time
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *apiTime) UnmarshalJSON(data []byte) error {
+	*t = apiTime(parseAPITime(data))
+	return nil
+}
+
+// parseAPITime parses a JSON timestamp, returning the zero time if it can't be understood
+func parseAPITime(data []byte) time.Time {
+	if bytes.Equal(data, []byte("null")) {
+		return time.Time{}
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil || value == "" {
+		return time.Time{}
+	}
+
+	for _, layout := range apiTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,60 @@
+package civogo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseAPITime(t *testing.T) {
+	tests := []struct {
+		json     string
+		expected time.Time
+	}{
+		{`""`, time.Time{}},
+		{`null`, time.Time{}},
+		{`"not a time"`, time.Time{}},
+		{`12345`, time.Time{}},
+		{`"2024-05-01T10:00:00Z"`, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+		{`"2024-05-01T11:00:00.5+01:00"`, time.Date(2024, 5, 1, 10, 0, 0, 500000000, time.UTC)},
+		{`"2024-05-01 10:00:00"`, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+		{`"2024-05-01 11:00:00 +0100"`, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+		{`"2024-05-01"`, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		got := parseAPITime([]byte(test.json))
+		if !got.Equal(test.expected) {
+			t.Errorf("Expected %s to parse as %v, got %v", test.json, test.expected, got)
+		}
+	}
+}
+
+func TestDiskImageCreatedAt(t *testing.T) {
+	var images []DiskImage
+	err := json.Unmarshal([]byte(`[
+		{"id": "1", "name": "ubuntu-22.04", "created_at": "2024-05-01T10:00:00Z", "distribution_default": true},
+		{"id": "2", "name": "debian-12", "created_at": ""},
+		{"id": "3", "name": "rocky-9", "created_at": null},
+		{"id": "4", "name": "alpine", "created_at": "2024-05-01 10:00:00"}
+	]`), &images)
+	if err != nil {
+		t.Errorf("Unmarshal returned an error: %s", err)
+		return
+	}
+
+	if len(images) != 4 {
+		t.Errorf("Expected %d, got %d", 4, len(images))
+		return
+	}
+	expected := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	if !images[0].CreatedAt.Equal(expected) || !images[3].CreatedAt.Equal(expected) {
+		t.Errorf("Expected %v, got %v and %v", expected, images[0].CreatedAt, images[3].CreatedAt)
+	}
+	if !images[1].CreatedAt.IsZero() || !images[2].CreatedAt.IsZero() {
+		t.Errorf("Expected zero times, got %v and %v", images[1].CreatedAt, images[2].CreatedAt)
+	}
+	if images[0].Name != "ubuntu-22.04" || !images[0].DistributionDefault {
+		t.Errorf("Expected the other fields to be decoded, got %+v", images[0])
+	}
+}
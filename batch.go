@@ -0,0 +1,29 @@
+package civogo
+
+import "sync"
+
+// batchWorkers is how many requests the batch helpers, such as CreateInstances and
+// DeleteDiskImages, run at once
+const batchWorkers = 5
+
+// forEachConcurrently calls fn with each index from 0 to n-1, running up to batchWorkers calls at
+// once, and returns when they've all finished. fn is expected to record its own result by index
+func forEachConcurrently(n int, fn func(i int)) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < batchWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -34,3 +34,60 @@ func (c *Client) ListCharges(from, to time.Time) ([]Charge, error) {
 
 	return charges, nil
 }
+
+// maxChargesRange is the longest period the charges endpoint accepts in one request
+const maxChargesRange = 31 * 24 * time.Hour
+
+// GetCharges returns the charges for each resource between from and to, e.g. for exporting
+// spend to a cost report. from must not be after to, otherwise it returns a
+// ParameterDateRangeError. The API only accepts up to 31 days at a time, so a longer period is
+// fetched in back-to-back windows of 31 days (not calendar months) that don't overlap, and the
+// charges for the same code and label in different windows are merged into one, with their hours
+// added up
+func (c *Client) GetCharges(from, to time.Time) ([]Charge, error) {
+	if from.After(to) {
+		err := fmt.Errorf("from (%s) is after to (%s)", from.Format(time.RFC3339), to.Format(time.RFC3339))
+		return nil, ParameterDateRangeError.wrap(err)
+	}
+
+	charges := make([]Charge, 0)
+	merged := map[[2]string]int{}
+	for start := from; ; {
+		// the range is inclusive at both ends, so the next window starts a second after this one
+		end := start.Add(maxChargesRange - time.Second)
+		if end.After(to) {
+			end = to
+		}
+
+		page, err := c.ListCharges(start, end)
+		if err != nil {
+			return nil, err
+		}
+		for _, charge := range page {
+			key := [2]string{charge.Code, charge.Label}
+			i, ok := merged[key]
+			if !ok {
+				merged[key] = len(charges)
+				charges = append(charges, charge)
+				continue
+			}
+
+			existing := &charges[i]
+			existing.NumHours += charge.NumHours
+			if charge.From.Before(existing.From) {
+				existing.From = charge.From
+			}
+			if charge.To.After(existing.To) {
+				existing.To = charge.To
+			}
+			if charge.SizeGigabytes != 0 {
+				existing.SizeGigabytes = charge.SizeGigabytes
+			}
+		}
+
+		if !end.Before(to) {
+			return charges, nil
+		}
+		start = end.Add(time.Second)
+	}
+}
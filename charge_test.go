@@ -1,6 +1,10 @@
 package civogo
 
 import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -61,3 +65,41 @@ func TestListCharges(t *testing.T) {
 		t.Errorf("Expected %d, got %d", 200, got[0].SizeGigabytes)
 	}
 }
+
+func TestGetCharges(t *testing.T) {
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ranges = append(ranges, req.URL.Query().Get("from")+" "+req.URL.Query().Get("to"))
+		rw.Write([]byte(`[{"code": "instance-g1.small", "label": "furry-apple.example.com", "num_hours": 24, "from": "` +
+			req.URL.Query().Get("from") + `", "to": "` + req.URL.Query().Get("to") + `"}]`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	from, _ := time.Parse(time.RFC3339, "2016-01-01T00:00:00Z")
+	to, _ := time.Parse(time.RFC3339, "2016-03-01T00:00:00Z")
+
+	got, err := client.GetCharges(from, to)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+
+	expected := []string{
+		"2016-01-01T00:00:00Z 2016-01-31T23:59:59Z",
+		"2016-02-01T00:00:00Z 2016-03-01T00:00:00Z",
+	}
+	if !reflect.DeepEqual(ranges, expected) {
+		t.Errorf("Expected %v, got %v", expected, ranges)
+	}
+	if len(got) != 1 || got[0].Code != "instance-g1.small" || got[0].NumHours != 48 {
+		t.Errorf("Expected the charges from each window merged into one, got %+v", got)
+	}
+	if !got[0].From.Equal(from) || !got[0].To.Equal(to) {
+		t.Errorf("Expected the merged charge to run from %s to %s, got %s to %s", from, to, got[0].From, got[0].To)
+	}
+
+	if _, err := client.GetCharges(to, from); !errors.Is(err, ParameterDateRangeError) {
+		t.Errorf("Expected %v, got %v", ParameterDateRangeError, err)
+	}
+}
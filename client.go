@@ -2,6 +2,7 @@ package civogo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,7 +11,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/civo/civogo/utils"
 )
@@ -24,6 +29,25 @@ type Client struct {
 	LastJSONResponse string
 
 	httpClient *http.Client
+	mu         *sync.Mutex
+
+	maxRetries     int
+	retryBaseDelay time.Duration
+	rateLimiter    *rateLimiter
+
+	getTimeout    time.Duration
+	mutateTimeout time.Duration
+	uploadTimeout time.Duration
+
+	requestHooks  []func(*http.Request)
+	responseHooks []func(*http.Response, time.Duration)
+
+	dryRun         bool
+	metrics        MetricsCollector
+	organisationID string
+	dnsMinimumTTL  int
+
+	diskImageCache *diskImageCache
 }
 
 // Component is a struct to define a User-Agent from a client
@@ -31,11 +55,22 @@ type Component struct {
 	ID, Name, Version string
 }
 
-// HTTPError is the error returned when the API fails with an HTTP error
+// HTTPError is the error returned when the API fails with an HTTP error. The errors returned by
+// the client methods wrap it, so it can be extracted with errors.As
 type HTTPError struct {
+	// Code is the HTTP status code
 	Code   int
 	Status string
+	// Reason is the raw response body
 	Reason string
+	// Retries is how many times the request was retried before giving up
+	Retries int
+	// RequestID is the X-Civo-Request-Id header of the response, Civo support will ask for it
+	RequestID string
+	// APIReason is the reason (and details) decoded from the response body, if there is one
+	APIReason string
+	// RetryAfter is how long the Retry-After header of the response asked to wait, if it had one
+	RetryAfter time.Duration
 }
 
 // Result is the result of a SimpleResponse
@@ -67,6 +102,9 @@ type ValueAdvanceClientForTesting struct {
 const ResultSuccess = "success"
 
 func (e HTTPError) Error() string {
+	if e.Retries > 0 {
+		return fmt.Sprintf("%d: %s, %s (after %d retries)", e.Code, e.Status, e.Reason, e.Retries)
+	}
 	return fmt.Sprintf("%d: %s, %s", e.Code, e.Status, e.Reason)
 }
 
@@ -93,15 +131,71 @@ func NewClientWithURL(apiKey, civoAPIURL, region string) (*Client, error) {
 		httpClient: &http.Client{
 			Transport: httpTransport,
 		},
+		mu:            &sync.Mutex{},
+		metrics:       noopMetricsCollector{},
+		dnsMinimumTTL: DefaultDNSMinimumTTL,
 	}
 	return client, nil
 }
 
+// NewClientWithHTTPClient initializes a Client with a specific API URL that sends its requests
+// through httpClient, e.g. one with a proxy, mTLS or tracing transport
+func NewClientWithHTTPClient(apiKey, civoAPIURL, region string, httpClient *http.Client) (*Client, error) {
+	client, err := NewClientWithURL(apiKey, civoAPIURL, region)
+	if err != nil {
+		return nil, err
+	}
+	client.SetHTTPClient(httpClient)
+	return client, nil
+}
+
+// SetHTTPClient replaces the http.Client used to talk to the API. The Authorization and
+// User-Agent headers are still set on every request, whatever transport httpClient uses.
+// A nil httpClient is ignored
+func (c *Client) SetHTTPClient(httpClient *http.Client) {
+	if httpClient == nil {
+		return
+	}
+	c.httpClient = httpClient
+}
+
+// WithRegion returns a copy of the client scoped to region, leaving the original untouched, so
+// calls to several regions can be made concurrently. The copy shares everything else with the
+// original (its http.Client, rate limit, retry policy, timeouts, hooks, dry-run mode, metrics
+// collector, organisation and disk image cache), but has its own LastJSONResponse, and hooks
+// registered on one afterwards don't affect the other
+func (c *Client) WithRegion(region string) *Client {
+	cp := *c
+	cp.Region = region
+	cp.mu = &sync.Mutex{}
+	cp.LastJSONResponse = ""
+	cp.requestHooks = append([]func(*http.Request){}, c.requestHooks...)
+	cp.responseHooks = append([]func(*http.Response, time.Duration){}, c.responseHooks...)
+	return &cp
+}
+
 // NewClient initializes a Client connecting to the production API
 func NewClient(apiKey, region string) (*Client, error) {
 	return NewClientWithURL(apiKey, "https://api.civo.com", region)
 }
 
+// NewClientFromEnv initializes a Client from the environment: the API key from CIVO_TOKEN, which
+// is required, the region from CIVO_REGION and, if it's set, the API URL from CIVO_API_URL
+// rather than the production API
+func NewClientFromEnv() (*Client, error) {
+	apiKey := os.Getenv("CIVO_TOKEN")
+	if apiKey == "" {
+		err := errors.New("the CIVO_TOKEN environment variable isn't set, it must hold your API key")
+		return nil, NoAPIKeySuppliedError.wrap(err)
+	}
+
+	region := os.Getenv("CIVO_REGION")
+	if apiURL := os.Getenv("CIVO_API_URL"); apiURL != "" {
+		return NewClientWithURL(apiKey, apiURL, region)
+	}
+	return NewClient(apiKey, region)
+}
+
 // NewAdvancedClientForTesting initializes a Client connecting to a local test server and allows for specifying methods
 func NewAdvancedClientForTesting(responses []ConfigAdvanceClientForTesting) (*Client, *httptest.Server, error) {
 	var responseSent bool
@@ -196,38 +290,263 @@ func (c *Client) sendRequest(req *http.Request) ([]byte, error) {
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Content-Encoding", "gzip")
 	req.Header.Set("Authorization", fmt.Sprintf("bearer %s", c.APIKey))
-
-	c.httpClient.Transport = &http.Transport{
-		DisableCompression: false,
+	if c.organisationID != "" {
+		req.Header.Set(organisationHeader, c.organisationID)
 	}
 
 	if req.Method == "GET" || req.Method == "DELETE" {
-		// add the region param
+		// add the region param, unless the caller has already scoped the request to a region
 		param := req.URL.Query()
-		param.Add("region", c.Region)
+		if param.Get("region") == "" {
+			param.Set("region", c.Region)
+		}
 		req.URL.RawQuery = param.Encode()
 	}
 
-	resp, err := c.httpClient.Do(req)
+	if c.dryRun && req.Method != "GET" {
+		return c.dryRunResponse(req)
+	}
+
+	timeout := c.mutateTimeout
+	if req.Method == "GET" {
+		timeout = c.getTimeout
+	}
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	status := 0
+	if c.metrics != nil {
+		requestStart := time.Now()
+		defer func() {
+			c.metrics.ObserveRequest(req.Method, req.URL.Path, status, time.Since(requestStart))
+		}()
+	}
+
+	var body []byte
+	var err error
+	for attempt := 0; ; attempt++ {
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.wait(req.Context()); err != nil {
+				return nil, &url.Error{Op: req.Method, URL: req.URL.String(), Err: err}
+			}
+		}
+
+		for _, hook := range c.requestHooks {
+			hook(cloneRequestForHook(req))
+		}
+
+		start := time.Now()
+		var resp *http.Response
+		resp, err = c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		status = resp.StatusCode
+		body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		latency := time.Since(start)
+		for _, hook := range c.responseHooks {
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			hook(resp, latency)
+		}
+		c.mu.Lock()
+		c.LastJSONResponse = string(body)
+		c.mu.Unlock()
+
+		if resp.StatusCode < 300 {
+			return body, err
+		}
+
+		httpErr := HTTPError{
+			Code:      resp.StatusCode,
+			Status:    resp.Status,
+			Reason:    string(body),
+			Retries:   attempt,
+			RequestID: resp.Header.Get("X-Civo-Request-Id"),
+		}
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			httpErr.RetryAfter = retryAfter
+		}
+		if attempt >= c.maxRetries || !isRetryable(req.Method, resp.StatusCode) {
+			return nil, httpErr
+		}
+
+		delay := c.retryBaseDelay << attempt
+		if httpErr.RetryAfter > 0 && resp.StatusCode == http.StatusTooManyRequests {
+			delay = httpErr.RetryAfter
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, httpErr
+		case <-time.After(delay):
+		}
+	}
+}
+
+// SetTimeouts limits how long requests can take, by kind: get for GET requests, mutate for the
+// requests that change something (POST, PUT, PATCH and DELETE) and upload for sending a disk
+// image with UploadDiskImage, or each chunk of UploadDiskImageResumable. The limit covers any
+// retries of the request. A timeout of 0 means no limit, which is the default for all three
+func (c *Client) SetTimeouts(get, mutate, upload time.Duration) {
+	c.getTimeout = get
+	c.mutateTimeout = mutate
+	c.uploadTimeout = upload
+}
+
+// withUploadTimeout bounds ctx by the client's upload timeout, if it has one
+func (c *Client) withUploadTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.uploadTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.uploadTimeout)
+}
+
+// redactedAuthorization replaces the API key in the Authorization header given to request hooks
+const redactedAuthorization = "bearer [REDACTED]"
+
+// cloneRequestForHook copies req for a request hook, with a body of its own so a hook reading it
+// doesn't empty the body that's sent, and the API key redacted so a hook logging the headers
+// doesn't leak it
+func cloneRequestForHook(req *http.Request) *http.Request {
+	clone := req.Clone(req.Context())
+	if clone.Header.Get("Authorization") != "" {
+		clone.Header.Set("Authorization", redactedAuthorization)
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return clone
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			clone.Body = body
+			return clone
+		}
+	}
+
+	body, _ := io.ReadAll(req.Body)
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	clone.Body = io.NopCloser(bytes.NewReader(body))
+	return clone
+}
+
+// OnRequest registers a hook called before every request is sent to the API, including retries.
+// The hook gets a copy of the request, so changes it makes aren't sent, and its Authorization
+// header is redacted
+func (c *Client) OnRequest(hook func(*http.Request)) {
+	if hook != nil {
+		c.requestHooks = append(c.requestHooks, hook)
+	}
+}
+
+// OnResponse registers a hook called with every response from the API and how long the request
+// took. The body can be read by each hook without affecting the client
+func (c *Client) OnResponse(hook func(*http.Response, time.Duration)) {
+	if hook != nil {
+		c.responseHooks = append(c.responseHooks, hook)
+	}
+}
+
+// SetDryRun turns dry-run mode on or off. In dry-run mode requests that change anything (POST,
+// PUT, PATCH and DELETE) aren't sent: the client logs the request it would have made and returns
+// a successful SimpleResponse instead. GET requests are still sent, so reads see the real account.
+// Methods that decode a richer response will get mostly empty objects back
+func (c *Client) SetDryRun(dryRun bool) {
+	c.dryRun = dryRun
+}
+
+// organisationHeader is the header that scopes a request to an organisation
+const organisationHeader = "X-Civo-Organisation"
+
+// SetOrganisationID scopes every request the client sends to the organisation with the given ID,
+// so listings return that organisation's resources, e.g. when managing several customers'
+// organisations with one key. An empty id goes back to the API key's own account
+func (c *Client) SetOrganisationID(id string) {
+	c.organisationID = id
+}
+
+// SetBaseURL points the client at another API, e.g. a staging environment or a mock server for
+// end-to-end tests, instead of the one it was created with. baseURL must be an absolute http or
+// https URL, otherwise a ParameterURLInvalidError is returned and the client is unchanged
+func (c *Client) SetBaseURL(baseURL string) error {
+	parsedURL, err := url.Parse(baseURL)
 	if err != nil {
-		return nil, err
+		return ParameterURLInvalidError.wrap(err)
+	}
+	if (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		err := fmt.Errorf("%q isn't an http or https URL", baseURL)
+		return ParameterURLInvalidError.wrap(err)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	// request paths start with a slash, so a trailing one here would double it up
+	parsedURL.Path = strings.TrimSuffix(parsedURL.Path, "/")
+	parsedURL.RawPath = strings.TrimSuffix(parsedURL.RawPath, "/")
+	c.BaseURL = parsedURL
+	return nil
+}
+
+// dryRunResponse logs req and returns the response used in its place in dry-run mode
+func (c *Client) dryRunResponse(req *http.Request) ([]byte, error) {
+	var params []byte
+	if req.Body != nil {
+		params, _ = io.ReadAll(req.Body)
+		req.Body.Close()
+	}
+	log.Printf("civogo dry-run: %s %s %s", req.Method, req.URL.String(), params)
+
+	body := []byte(`{"result":"success"}`)
+	c.mu.Lock()
 	c.LastJSONResponse = string(body)
+	c.mu.Unlock()
+	return body, nil
+}
 
-	if resp.StatusCode >= 300 {
-		return nil, HTTPError{Code: resp.StatusCode, Status: resp.Status, Reason: string(body)}
+// SetRetryPolicy makes the client retry idempotent (GET) requests that fail with a 429 or 5xx
+// status up to maxRetries times, waiting baseDelay doubled on each attempt in between, or as
+// long as the API asks in the Retry-After header of a 429. A maxRetries of 0 disables retrying
+func (c *Client) SetRetryPolicy(maxRetries int, baseDelay time.Duration) {
+	c.maxRetries = maxRetries
+	c.retryBaseDelay = baseDelay
+}
+
+func isRetryable(method string, statusCode int) bool {
+	if method != "GET" {
+		return false
 	}
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
 
-	return body, err
+// parseRetryAfter reads a Retry-After header, which is either a number of seconds or an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
 }
 
 // SendGetRequest sends a correctly authenticated get request to the API server
 func (c *Client) SendGetRequest(requestURL string) ([]byte, error) {
+	return c.SendGetRequestWithContext(context.Background(), requestURL)
+}
+
+// SendGetRequestWithContext sends a correctly authenticated get request to the API server, bound to ctx
+func (c *Client) SendGetRequestWithContext(ctx context.Context, requestURL string) ([]byte, error) {
 	u := c.prepareClientURL(requestURL)
-	req, err := http.NewRequest("GET", u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -237,12 +556,17 @@ func (c *Client) SendGetRequest(requestURL string) ([]byte, error) {
 
 // SendPostRequest sends a correctly authenticated post request to the API server
 func (c *Client) SendPostRequest(requestURL string, params interface{}) ([]byte, error) {
+	return c.SendPostRequestWithContext(context.Background(), requestURL, params)
+}
+
+// SendPostRequestWithContext sends a correctly authenticated post request to the API server, bound to ctx
+func (c *Client) SendPostRequestWithContext(ctx context.Context, requestURL string, params interface{}) ([]byte, error) {
 	u := c.prepareClientURL(requestURL)
 
 	// we create a new buffer and encode everything to json to send it in the request
 	jsonValue, _ := json.Marshal(params)
 
-	req, err := http.NewRequest("POST", u.String(), bytes.NewBuffer(jsonValue))
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), bytes.NewBuffer(jsonValue))
 	if err != nil {
 		return nil, err
 	}
@@ -251,12 +575,36 @@ func (c *Client) SendPostRequest(requestURL string, params interface{}) ([]byte,
 
 // SendPutRequest sends a correctly authenticated put request to the API server
 func (c *Client) SendPutRequest(requestURL string, params interface{}) ([]byte, error) {
+	return c.SendPutRequestWithContext(context.Background(), requestURL, params)
+}
+
+// SendPutRequestWithContext sends a correctly authenticated put request to the API server, bound to ctx
+func (c *Client) SendPutRequestWithContext(ctx context.Context, requestURL string, params interface{}) ([]byte, error) {
 	u := c.prepareClientURL(requestURL)
 
 	// we create a new buffer and encode everything to json to send it in the request
 	jsonValue, _ := json.Marshal(params)
 
-	req, err := http.NewRequest("PUT", u.String(), bytes.NewBuffer(jsonValue))
+	req, err := http.NewRequestWithContext(ctx, "PUT", u.String(), bytes.NewBuffer(jsonValue))
+	if err != nil {
+		return nil, err
+	}
+	return c.sendRequest(req)
+}
+
+// SendPatchRequest sends a correctly authenticated patch request to the API server, for partial updates
+func (c *Client) SendPatchRequest(requestURL string, params interface{}) ([]byte, error) {
+	return c.SendPatchRequestWithContext(context.Background(), requestURL, params)
+}
+
+// SendPatchRequestWithContext sends a correctly authenticated patch request to the API server, bound to ctx
+func (c *Client) SendPatchRequestWithContext(ctx context.Context, requestURL string, params interface{}) ([]byte, error) {
+	u := c.prepareClientURL(requestURL)
+
+	// we create a new buffer and encode everything to json to send it in the request
+	jsonValue, _ := json.Marshal(params)
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", u.String(), bytes.NewBuffer(jsonValue))
 	if err != nil {
 		return nil, err
 	}
@@ -265,8 +613,13 @@ func (c *Client) SendPutRequest(requestURL string, params interface{}) ([]byte,
 
 // SendDeleteRequest sends a correctly authenticated delete request to the API server
 func (c *Client) SendDeleteRequest(requestURL string) ([]byte, error) {
+	return c.SendDeleteRequestWithContext(context.Background(), requestURL)
+}
+
+// SendDeleteRequestWithContext sends a correctly authenticated delete request to the API server, bound to ctx
+func (c *Client) SendDeleteRequestWithContext(ctx context.Context, requestURL string) ([]byte, error) {
 	u := c.prepareClientURL(requestURL)
-	req, err := http.NewRequest("DELETE", u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", u.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -289,3 +642,20 @@ func (c *Client) SetUserAgent(component *Component) {
 		c.UserAgent = fmt.Sprintf("%s/%s-%s %s", component.Name, component.Version, component.ID, c.UserAgent)
 	}
 }
+
+// SetAppIdentity identifies the application built on civogo to the API, setting the User-Agent to
+// "civogo/<version> <name>/<version>" so Civo can tell its traffic apart for support and rate
+// limiting. Calling it again replaces the identity; spaces and slashes in name or version are
+// replaced with dashes so the header stays well-formed. The Authorization header is unaffected
+func (c *Client) SetAppIdentity(name, version string) {
+	clean := strings.NewReplacer(" ", "-", "\t", "-", "/", "-")
+	product := clean.Replace(strings.TrimSpace(name))
+	if product == "" {
+		c.UserAgent = "civogo/" + utils.GetVersion()
+		return
+	}
+	if version = clean.Replace(strings.TrimSpace(version)); version != "" {
+		product += "/" + version
+	}
+	c.UserAgent = fmt.Sprintf("civogo/%s %s", utils.GetVersion(), product)
+}
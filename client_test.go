@@ -1,7 +1,14 @@
 package civogo
 
 import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 )
@@ -55,3 +62,489 @@ func Test_AdvancedClientForTesting(t *testing.T) {
 	g.Expect(len(domains)).To(Equal(2))
 
 }
+
+func TestRetryPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	calls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls[req.Method]++
+		if req.Method == "GET" && calls[req.Method] == 1 {
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			rw.Write([]byte(`{"code":"too_many_requests"}`))
+			return
+		}
+		if req.Method == "GET" && calls[req.Method] == 2 {
+			rw.WriteHeader(http.StatusBadGateway)
+			rw.Write([]byte(`{"code":"bad_gateway"}`))
+			return
+		}
+		if req.Method == "POST" {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			rw.Write([]byte(`{"code":"unavailable"}`))
+			return
+		}
+		rw.Write([]byte(`{"result":"success"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientForTestingWithServer(server)
+	g.Expect(err).To(BeNil())
+	client.SetRetryPolicy(3, time.Millisecond)
+
+	body, err := client.SendGetRequest("/v2/ping")
+	g.Expect(err).To(BeNil())
+	g.Expect(string(body)).To(Equal(`{"result":"success"}`))
+	g.Expect(calls["GET"]).To(Equal(3))
+
+	_, err = client.SendPostRequest("/v2/ping", nil)
+	var httpErr HTTPError
+	g.Expect(errors.As(err, &httpErr)).To(BeTrue())
+	g.Expect(httpErr.Code).To(Equal(http.StatusServiceUnavailable))
+	g.Expect(httpErr.Retries).To(Equal(0))
+	g.Expect(calls["POST"]).To(Equal(1))
+}
+
+func TestRetryPolicyGivesUp(t *testing.T) {
+	g := NewWithT(t)
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+		rw.WriteHeader(http.StatusInternalServerError)
+		rw.Write([]byte(`{"status":500}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientForTestingWithServer(server)
+	g.Expect(err).To(BeNil())
+	client.SetRetryPolicy(2, time.Millisecond)
+
+	_, err = client.SendGetRequest("/v2/ping")
+	var httpErr HTTPError
+	g.Expect(errors.As(err, &httpErr)).To(BeTrue())
+	g.Expect(httpErr.Retries).To(Equal(2))
+	g.Expect(err.Error()).To(ContainSubstring("after 2 retries"))
+	g.Expect(calls).To(Equal(3))
+}
+
+type headerRecordingTransport struct {
+	headers http.Header
+}
+
+func (t *headerRecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.headers = req.Header.Clone()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNewClientWithHTTPClient(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(`{"result":"success"}`))
+	}))
+	defer server.Close()
+
+	transport := &headerRecordingTransport{}
+	client, err := NewClientWithHTTPClient("TEST-API-KEY", server.URL, "TEST", &http.Client{Transport: transport})
+	g.Expect(err).To(BeNil())
+
+	g.Expect(client.Ping()).To(Succeed())
+	g.Expect(transport.headers.Get("Authorization")).To(Equal("bearer TEST-API-KEY"))
+	g.Expect(transport.headers.Get("User-Agent")).To(Equal(client.UserAgent))
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	g := NewWithT(t)
+
+	t.Setenv("CIVO_TOKEN", "")
+	t.Setenv("CIVO_REGION", "LON1")
+	t.Setenv("CIVO_API_URL", "")
+	_, err := NewClientFromEnv()
+	g.Expect(errors.Is(err, NoAPIKeySuppliedError)).To(BeTrue())
+
+	t.Setenv("CIVO_TOKEN", "TEST-API-KEY")
+	client, err := NewClientFromEnv()
+	g.Expect(err).To(BeNil())
+	g.Expect(client.APIKey).To(Equal("TEST-API-KEY"))
+	g.Expect(client.Region).To(Equal("LON1"))
+	g.Expect(client.BaseURL.String()).To(Equal("https://api.civo.com"))
+
+	t.Setenv("CIVO_API_URL", "http://localhost:3000")
+	client, err = NewClientFromEnv()
+	g.Expect(err).To(BeNil())
+	g.Expect(client.BaseURL.String()).To(Equal("http://localhost:3000"))
+}
+
+func TestSetBaseURL(t *testing.T) {
+	g := NewWithT(t)
+
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		gotPath = req.URL.Path
+		rw.Write([]byte(`{"result":"success"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("TEST-API-KEY", "TEST")
+	g.Expect(err).To(BeNil())
+
+	for _, invalid := range []string{"", "api.civo.com", "ftp://api.civo.com", "https://", "://bad"} {
+		err := client.SetBaseURL(invalid)
+		g.Expect(errors.Is(err, ParameterURLInvalidError)).To(BeTrue(), invalid)
+	}
+	g.Expect(client.BaseURL.String()).To(Equal("https://api.civo.com"))
+
+	g.Expect(client.SetBaseURL(server.URL + "/staging/")).To(Succeed())
+	g.Expect(client.Ping()).To(Succeed())
+	g.Expect(gotPath).To(Equal("/staging/v2/ping"))
+}
+
+func TestRequestResponseHooks(t *testing.T) {
+	g := NewWithT(t)
+
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		gotAuth = req.Header.Get("Authorization")
+		rw.Write([]byte(`{"result":"success"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientForTestingWithServer(server)
+	g.Expect(err).To(BeNil())
+
+	var requests []string
+	var responses []string
+	client.OnRequest(nil)
+	client.OnRequest(func(req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		req.Header.Set("Authorization", "bearer tampered")
+	})
+	client.OnResponse(func(resp *http.Response, latency time.Duration) {
+		body, _ := io.ReadAll(resp.Body)
+		responses = append(responses, string(body))
+		g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		g.Expect(latency).To(BeNumerically(">", 0))
+	})
+
+	body, err := client.SendGetRequest("/v2/ping")
+	g.Expect(err).To(BeNil())
+	g.Expect(string(body)).To(Equal(`{"result":"success"}`))
+	g.Expect(gotAuth).To(Equal("bearer TEST-API-KEY"))
+	g.Expect(requests).To(Equal([]string{"GET /v2/ping"}))
+	g.Expect(responses).To(Equal([]string{`{"result":"success"}`}))
+}
+
+func TestRequestHookRedactsAuthorization(t *testing.T) {
+	g := NewWithT(t)
+
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		gotAuth = req.Header.Get("Authorization")
+		rw.Write([]byte(`{"result":"success"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientForTestingWithServer(server)
+	g.Expect(err).To(BeNil())
+
+	var hookedAuth string
+	client.OnRequest(func(req *http.Request) {
+		hookedAuth = req.Header.Get("Authorization")
+	})
+
+	_, err = client.SendGetRequest("/v2/ping")
+	g.Expect(err).To(BeNil())
+	g.Expect(hookedAuth).To(Equal("bearer [REDACTED]"))
+	g.Expect(hookedAuth).NotTo(ContainSubstring("TEST-API-KEY"))
+	g.Expect(gotAuth).To(Equal("bearer TEST-API-KEY"))
+}
+
+func TestRequestHookReadingBody(t *testing.T) {
+	g := NewWithT(t)
+
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		received = append(received, string(body))
+		rw.Write([]byte(`{"result":"success"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientForTestingWithServer(server)
+	g.Expect(err).To(BeNil())
+
+	var hooked []string
+	client.OnRequest(func(req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		hooked = append(hooked, string(body))
+	})
+
+	_, err = client.SendPostRequest("/v2/webhooks", map[string]string{"url": "https://example.com"})
+	g.Expect(err).To(BeNil())
+
+	// a body without GetBody is buffered rather than shared with the hook
+	req, err := http.NewRequest("PUT", server.URL+"/v2/webhooks/1", io.MultiReader(strings.NewReader(`{"url":"https://example.org"}`)))
+	g.Expect(err).To(BeNil())
+	_, err = client.sendRequest(req)
+	g.Expect(err).To(BeNil())
+
+	expected := []string{`{"url":"https://example.com"}`, `{"url":"https://example.org"}`}
+	g.Expect(hooked).To(Equal(expected))
+	g.Expect(received).To(Equal(expected))
+}
+
+func TestDecodeErrorKeepsHTTPError(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("X-Civo-Request-Id", "req-12345")
+		rw.WriteHeader(http.StatusNotFound)
+		rw.Write([]byte(`{"code":"database_disk_image_not_found","reason":"The requested disk image could not be found","details":"ID 1"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientForTestingWithServer(server)
+	g.Expect(err).To(BeNil())
+
+	_, err = client.GetDiskImage("1")
+	g.Expect(errors.Is(err, DatabaseDiskImageNotFoundError)).To(BeTrue())
+	g.Expect(err.Error()).To(Equal("DatabaseDiskImageNotFoundError: The requested disk image could not be found, ID 1"))
+
+	var httpErr HTTPError
+	g.Expect(errors.As(err, &httpErr)).To(BeTrue())
+	g.Expect(httpErr.Code).To(Equal(http.StatusNotFound))
+	g.Expect(httpErr.RequestID).To(Equal("req-12345"))
+	g.Expect(httpErr.APIReason).To(Equal("The requested disk image could not be found, ID 1"))
+}
+
+func TestDecodeErrorAuthentication(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		status   int
+		body     string
+		expected error
+	}{
+		{http.StatusUnauthorized, `{"code":"unauthorized","reason":"Invalid API key"}`, AuthenticationError},
+		{http.StatusForbidden, `Forbidden`, AuthenticationError},
+		{http.StatusUnauthorized, `{"code":"authentication_failed","reason":"Authentication failed"}`, AuthenticationFailedError},
+		{http.StatusBadRequest, `{"code":"unknown"}`, CommonError},
+	}
+
+	for _, test := range tests {
+		err := decodeError(HTTPError{Code: test.status, Status: http.StatusText(test.status), Reason: test.body})
+		g.Expect(errors.Is(err, test.expected)).To(BeTrue(), "status %d, got %v", test.status, err)
+
+		var httpErr HTTPError
+		g.Expect(errors.As(err, &httpErr)).To(BeTrue())
+		g.Expect(httpErr.Code).To(Equal(test.status))
+	}
+}
+
+func TestDecodeErrorRateLimit(t *testing.T) {
+	g := NewWithT(t)
+
+	retryAfter := "30"
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if retryAfter != "" {
+			rw.Header().Set("Retry-After", retryAfter)
+		}
+		rw.WriteHeader(http.StatusTooManyRequests)
+		rw.Write([]byte(`{"code":"too_many_requests","reason":"Slow down"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientForTestingWithServer(server)
+	g.Expect(err).To(BeNil())
+
+	_, err = client.ListRegions()
+	var rateLimitErr RateLimitError
+	g.Expect(errors.As(err, &rateLimitErr)).To(BeTrue())
+	g.Expect(rateLimitErr.RetryAfter).To(Equal(30 * time.Second))
+	g.Expect(err.Error()).To(Equal("RateLimitError: too many requests, retry after 30s"))
+
+	var httpErr HTTPError
+	g.Expect(errors.As(err, &httpErr)).To(BeTrue())
+	g.Expect(httpErr.Code).To(Equal(http.StatusTooManyRequests))
+	g.Expect(httpErr.APIReason).To(Equal("Slow down"))
+
+	retryAfter = ""
+	_, err = client.ListRegions()
+	g.Expect(errors.As(err, &rateLimitErr)).To(BeTrue())
+	g.Expect(rateLimitErr.RetryAfter).To(BeZero())
+}
+
+func TestSendPatchRequest(t *testing.T) {
+	g := NewWithT(t)
+
+	client, server, _ := NewAdvancedClientForTesting([]ConfigAdvanceClientForTesting{
+		{
+			Method: "PATCH",
+			Value: []ValueAdvanceClientForTesting{
+				{
+					RequestBody:  `{"label":"new"}`,
+					URL:          "/v2/disk_images/12345",
+					ResponseBody: `{"result":"success"}`,
+				},
+			},
+		},
+	})
+	defer server.Close()
+
+	body, err := client.SendPatchRequest("/v2/disk_images/12345", map[string]string{"label": "new"})
+	g.Expect(err).To(BeNil())
+	g.Expect(string(body)).To(Equal(`{"result":"success"}`))
+}
+
+func TestWithRegion(t *testing.T) {
+	g := NewWithT(t)
+
+	regions := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		regions <- req.URL.Query().Get("region")
+		rw.Write([]byte(`{"result":"success"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientForTestingWithServer(server)
+	g.Expect(err).To(BeNil())
+
+	g.Expect(client.Ping()).To(Succeed())
+	g.Expect(<-regions).To(Equal("TEST"))
+
+	regional := client.WithRegion("LON1")
+	g.Expect(regional.LastJSONResponse).To(BeEmpty())
+	g.Expect(regional.Ping()).To(Succeed())
+	g.Expect(<-regions).To(Equal("LON1"))
+	g.Expect(regional.APIKey).To(Equal(client.APIKey))
+	g.Expect(client.Region).To(Equal("TEST"))
+}
+
+func TestSetOrganisationID(t *testing.T) {
+	g := NewWithT(t)
+
+	organisations := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		organisations <- req.Header.Get("X-Civo-Organisation")
+		rw.Write([]byte(`{"result":"success"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientForTestingWithServer(server)
+	g.Expect(err).To(BeNil())
+
+	g.Expect(client.Ping()).To(Succeed())
+	g.Expect(<-organisations).To(BeEmpty())
+
+	client.SetOrganisationID("org-12345")
+	g.Expect(client.Ping()).To(Succeed())
+	g.Expect(<-organisations).To(Equal("org-12345"))
+
+	g.Expect(client.WithRegion("LON1").Ping()).To(Succeed())
+	g.Expect(<-organisations).To(Equal("org-12345"))
+
+	client.SetOrganisationID("")
+	g.Expect(client.Ping()).To(Succeed())
+	g.Expect(<-organisations).To(BeEmpty())
+}
+
+func TestSetTimeouts(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "GET" || req.URL.Path == "/upload" {
+			time.Sleep(50 * time.Millisecond)
+		}
+		rw.Write([]byte(`{"result":"success"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientForTestingWithServer(server)
+	g.Expect(err).To(BeNil())
+	g.Expect(client.Ping()).To(Succeed())
+
+	client.SetTimeouts(10*time.Millisecond, time.Second, 0)
+	err = client.Ping()
+	g.Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue(), "got %v", err)
+
+	_, err = client.SendPostRequest("/v2/networks", map[string]string{"label": "test"})
+	g.Expect(err).To(BeNil())
+
+	// upload timeouts apply to the upload URL rather than the API
+	client.SetTimeouts(0, 0, 10*time.Millisecond)
+	g.Expect(client.Ping()).To(Succeed())
+	md5sum, sha256sum := strings.Repeat("0", 32), strings.Repeat("0", 64)
+	err = client.UploadDiskImage(server.URL+"/upload", strings.NewReader("image"), 5, md5sum, sha256sum)
+	g.Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue(), "got %v", err)
+}
+
+func TestDryRun(t *testing.T) {
+	g := NewWithT(t)
+
+	calls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls[req.Method]++
+		if req.Method != "GET" {
+			rw.Write([]byte(`{"result":"success"}`))
+			return
+		}
+		rw.Write([]byte(`[{"id": "12345", "name": "my-network"}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientForTestingWithServer(server)
+	g.Expect(err).To(BeNil())
+	client.SetDryRun(true)
+
+	networks, err := client.ListNetworks()
+	g.Expect(err).To(BeNil())
+	g.Expect(len(networks)).To(Equal(1))
+
+	resp, err := client.DeleteNetwork("12345")
+	g.Expect(err).To(BeNil())
+	g.Expect(string(resp.Result)).To(Equal(ResultSuccess))
+
+	_, err = client.SendPostRequest("/v2/networks", map[string]string{"label": "test"})
+	g.Expect(err).To(BeNil())
+	_, err = client.SendPutRequest("/v2/networks/12345", map[string]string{"label": "test"})
+	g.Expect(err).To(BeNil())
+	g.Expect(client.WithRegion("LON1").dryRun).To(BeTrue())
+
+	g.Expect(calls).To(Equal(map[string]int{"GET": 1}))
+
+	client.SetDryRun(false)
+	_, err = client.DeleteNetwork("12345")
+	g.Expect(err).To(BeNil())
+	g.Expect(calls["DELETE"]).To(Equal(1))
+}
+
+func TestSetAppIdentity(t *testing.T) {
+	g := NewWithT(t)
+
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		headers = req.Header.Clone()
+		rw.Write([]byte(`{"result":"success"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientForTestingWithServer(server)
+	g.Expect(err).To(BeNil())
+
+	client.SetAppIdentity("my tool", "1.2.0")
+	g.Expect(client.UserAgent).To(Equal("civogo/dev my-tool/1.2.0"))
+
+	// calling it again replaces the identity rather than adding to it
+	client.SetAppIdentity("myapp", "2.0/beta")
+	g.Expect(client.UserAgent).To(Equal("civogo/dev myapp/2.0-beta"))
+
+	_, err = client.SendGetRequest("/v2/ping")
+	g.Expect(err).To(BeNil())
+	g.Expect(headers.Get("User-Agent")).To(Equal("civogo/dev myapp/2.0-beta"))
+	g.Expect(headers.Get("Authorization")).To(Equal("bearer TEST-API-KEY"))
+
+	client.SetAppIdentity("", "")
+	g.Expect(client.UserAgent).To(Equal("civogo/dev"))
+}
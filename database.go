@@ -2,9 +2,15 @@ package civogo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // DatabaseUserInfo represents the user information
@@ -34,6 +40,9 @@ type Database struct {
 	Status           string             `json:"status"`
 }
 
+// DatabaseStatusReady is the Status of a database that is up and can be backed up
+const DatabaseStatusReady = "Ready"
+
 // PaginatedDatabases is the structure for list response from DB endpoint
 type PaginatedDatabases struct {
 	Page    int        `json:"page"`
@@ -96,7 +105,12 @@ func (c *Client) ListDatabases() (*PaginatedDatabases, error) {
 
 // GetDatabase finds a database by the database UUID
 func (c *Client) GetDatabase(id string) (*Database, error) {
-	resp, err := c.SendGetRequest(fmt.Sprintf("/v2/databases/%s", id))
+	return c.GetDatabaseWithContext(context.Background(), id)
+}
+
+// GetDatabaseWithContext is GetDatabase bound to ctx
+func (c *Client) GetDatabaseWithContext(ctx context.Context, id string) (*Database, error) {
+	resp, err := c.SendGetRequestWithContext(ctx, fmt.Sprintf("/v2/databases/%s", id))
 	if err != nil {
 		return nil, decodeError(err)
 	}
@@ -109,6 +123,39 @@ func (c *Client) GetDatabase(id string) (*Database, error) {
 	return db, nil
 }
 
+// WaitForDatabaseStatus polls the database until its Status is status (e.g. "Ready"), returning
+// the database once it is. It gives up after timeout, or as soon as the database reaches a failed
+// state. pollInterval optionally overrides the default of 5 seconds between checks
+func (c *Client) WaitForDatabaseStatus(id, status string, timeout time.Duration, pollInterval ...time.Duration) (*Database, error) {
+	return c.WaitForDatabaseStatusWithContext(context.Background(), id, status, timeout, pollInterval...)
+}
+
+// WaitForDatabaseStatusWithContext is WaitForDatabaseStatus bound to ctx
+func (c *Client) WaitForDatabaseStatusWithContext(ctx context.Context, id, status string, timeout time.Duration, pollInterval ...time.Duration) (*Database, error) {
+	var db *Database
+	err := pollUntil(ctx, timeout, pollIntervalOrDefault(pollInterval), fmt.Sprintf("database %s to be %s", id, status), func(ctx context.Context) (bool, error) {
+		var err error
+		db, err = c.GetDatabaseWithContext(ctx, id)
+		if err != nil {
+			return false, err
+		}
+
+		if db.Status != status && (strings.EqualFold(db.Status, "failed") || strings.EqualFold(db.Status, "error")) {
+			err := fmt.Errorf("database %s is in the %s state", id, db.Status)
+			return false, DatabaseFailedError.wrap(err)
+		}
+		return db.Status == status, nil
+	})
+	if err != nil {
+		if errors.Is(err, DatabaseFailedError) {
+			return db, err
+		}
+		return nil, err
+	}
+
+	return db, nil
+}
+
 // DeleteDatabase deletes a database
 func (c *Client) DeleteDatabase(id string) (*SimpleResponse, error) {
 	resp, err := c.SendDeleteRequest(fmt.Sprintf("/v2/databases/%s", id))
@@ -207,3 +254,56 @@ func (c *Client) RestoreDatabase(id string, v *RestoreDatabaseRequest) (*SimpleR
 
 	return c.DecodeSimpleResponse(resp)
 }
+
+// ConnectionString returns a URI for connecting to the database with the given engine, either
+// "postgresql" (or "postgres") or "mysql". The host is the database's DNS entry when it has one,
+// otherwise its public IP, and TLS is required. Any other engine returns DatabaseEngineUnsupportedError,
+// and a database with no address at all returns DatabaseNoAddressError
+func (d *Database) ConnectionString(engine string) (string, error) {
+	var scheme string
+	query := url.Values{}
+	switch strings.ToLower(engine) {
+	case "postgresql", "postgres":
+		scheme = "postgresql"
+		query.Set("sslmode", "require")
+	case "mysql":
+		scheme = "mysql"
+		query.Set("ssl-mode", "REQUIRED")
+	default:
+		err := fmt.Errorf("unable to build a connection string for engine %q, only postgresql and mysql are supported", engine)
+		return "", DatabaseEngineUnsupportedError.wrap(err)
+	}
+
+	host := d.DNSEntry
+	if host == "" {
+		host = d.PublicIPv4
+	}
+	if host == "" {
+		host = d.PrivateIPv4
+	}
+	if host == "" {
+		err := fmt.Errorf("database %s has no address to connect to", d.ID)
+		return "", DatabaseNoAddressError.wrap(err)
+	}
+
+	username, password, port := d.Username, d.Password, d.Port
+	if username == "" && len(d.DatabaseUserInfo) > 0 {
+		username, password = d.DatabaseUserInfo[0].Username, d.DatabaseUserInfo[0].Password
+	}
+	if port == 0 && len(d.DatabaseUserInfo) > 0 {
+		port = d.DatabaseUserInfo[0].Port
+	}
+	if port != 0 {
+		host = net.JoinHostPort(host, strconv.Itoa(port))
+	}
+
+	u := url.URL{
+		Scheme:   scheme,
+		User:     url.UserPassword(username, password),
+		Host:     host,
+		Path:     "/",
+		RawQuery: query.Encode(),
+	}
+
+	return u.String(), nil
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/civo/civogo/utils"
 )
 
 // DatabaseBackup represents a backup
@@ -66,6 +68,18 @@ func (c *Client) ListDatabaseBackup(did string) (*PaginatedDatabaseBackup, error
 	return back, nil
 }
 
+// ListDatabaseBackups returns the backups of the database with the given ID
+func (c *Client) ListDatabaseBackups(dbID string) ([]DatabaseBackup, error) {
+	backups, err := c.ListDatabaseBackup(dbID)
+	if err != nil {
+		return nil, err
+	}
+	if backups.Items == nil {
+		return []DatabaseBackup{}, nil
+	}
+	return backups.Items, nil
+}
+
 // UpdateDatabaseBackup update database backup
 func (c *Client) UpdateDatabaseBackup(did string, v *DatabaseBackupUpdateRequest) (*DatabaseBackup, error) {
 	body, err := c.SendPutRequest(fmt.Sprintf("/v2/databases/%s/backups", did), v)
@@ -96,6 +110,37 @@ func (c *Client) CreateDatabaseBackup(did string, v *DatabaseBackupCreateRequest
 	return result, nil
 }
 
+// BackupDatabase takes an on-demand (manual) backup named name of the database with the given ID.
+// The database must be Ready, otherwise a DatabaseNotReadyError is returned and no backup is taken
+func (c *Client) BackupDatabase(dbID, name string) (*DatabaseBackup, error) {
+	db, err := c.GetDatabase(dbID)
+	if err != nil {
+		return nil, err
+	}
+	if db.Status != DatabaseStatusReady {
+		err := fmt.Errorf("database %s must be %s to back up, it is %s", dbID, DatabaseStatusReady, db.Status)
+		return nil, DatabaseNotReadyError.wrap(err)
+	}
+
+	return c.CreateDatabaseBackup(dbID, &DatabaseBackupCreateRequest{Name: name, Type: "manual", Region: c.Region})
+}
+
+// RestoreDatabaseBackup restores the database with the given ID from one of its backups, as a
+// restore called restoreName. Each restore needs its own name, so a random one is used if
+// restoreName is empty
+func (c *Client) RestoreDatabaseBackup(dbID, backupID, restoreName string) (*SimpleResponse, error) {
+	backup, err := c.GetDatabaseBackup(dbID, backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if restoreName == "" {
+		restoreName = utils.RandomName()
+	}
+
+	return c.RestoreDatabase(dbID, &RestoreDatabaseRequest{Name: restoreName, Backup: backup.Name, Region: c.Region})
+}
+
 // DeleteDatabaseBackup deletes a database backup
 func (c *Client) DeleteDatabaseBackup(dbid, id string) (*SimpleResponse, error) {
 	resp, err := c.SendDeleteRequest(fmt.Sprintf("/v2/databases/%s/backups/%s", dbid, id))
@@ -0,0 +1,107 @@
+package civogo
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestListDatabaseBackups(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/databases/12345/backups": `{"page": 1, "per_page": 20, "pages": 1, "items": [{"id": "backup-1", "name": "nightly", "database_id": "12345"}]}`,
+	})
+	defer server.Close()
+
+	got, err := client.ListDatabaseBackups("12345")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+
+	expected := []DatabaseBackup{{ID: "backup-1", Name: "nightly", DatabaseID: "12345"}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestBackupDatabase(t *testing.T) {
+	var created DatabaseBackupCreateRequest
+	posts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "POST" && req.URL.Path == "/v2/databases/12345/backups":
+			posts++
+			json.NewDecoder(req.Body).Decode(&created)
+			rw.Write([]byte(`{"id": "backup-1", "name": "before-upgrade", "database_id": "12345", "status": "Pending"}`))
+		case req.URL.Path == "/v2/databases/12345":
+			rw.Write([]byte(`{"id": "12345", "name": "db", "status": "Ready"}`))
+		case req.URL.Path == "/v2/databases/67890":
+			rw.Write([]byte(`{"id": "67890", "name": "db", "status": "Pending"}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	_, err := client.BackupDatabase("67890", "before-upgrade")
+	if !errors.Is(err, DatabaseNotReadyError) {
+		t.Errorf("Expected %v, got %v", DatabaseNotReadyError, err)
+	}
+	if posts != 0 {
+		t.Errorf("Expected no backup to be requested, got %d", posts)
+	}
+
+	got, err := client.BackupDatabase("12345", "before-upgrade")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.ID != "backup-1" {
+		t.Errorf("Expected %s, got %s", "backup-1", got.ID)
+	}
+
+	expected := DatabaseBackupCreateRequest{Name: "before-upgrade", Type: "manual", Region: "TEST"}
+	if !reflect.DeepEqual(created, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, created)
+	}
+}
+
+func TestRestoreDatabaseBackup(t *testing.T) {
+	var restore RestoreDatabaseRequest
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "POST" && req.URL.Path == "/v2/databases/12345/restore":
+			json.NewDecoder(req.Body).Decode(&restore)
+			rw.Write([]byte(`{"result": "success"}`))
+		case req.URL.Path == "/v2/databases/12345/backups/backup-1":
+			rw.Write([]byte(`{"id": "backup-1", "name": "nightly", "database_id": "12345"}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.RestoreDatabaseBackup("12345", "backup-1", "nightly-restore")
+	EnsureSuccessfulSimpleResponse(t, got, err)
+
+	expected := RestoreDatabaseRequest{Name: "nightly-restore", Backup: "nightly", Region: "TEST"}
+	if !reflect.DeepEqual(restore, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, restore)
+	}
+
+	got, err = client.RestoreDatabaseBackup("12345", "backup-1", "")
+	EnsureSuccessfulSimpleResponse(t, got, err)
+	if restore.Name == "" || restore.Name == "nightly" || restore.Backup != "nightly" {
+		t.Errorf("Expected a generated restore name for backup nightly, got %+v", restore)
+	}
+
+	_, err = client.RestoreDatabaseBackup("12345", "missing", "nightly-restore")
+	if err == nil {
+		t.Errorf("Expected an error restoring a missing backup")
+	}
+}
@@ -2,16 +2,31 @@ package civogo
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/go-querystring/query"
 	"golang.org/x/mod/semver"
 )
 
-// DiskImage represents a serialized structure
+// Disk image endpoints are region-scoped: listing and getting images use the Client's Region
+// unless a region is given explicitly (ListDiskImagesInRegion, GetDiskImageInRegion or
+// DiskImageListOptions.Region), and custom images are only visible in the region they were
+// created in (CreateDiskImageParams.Region).
+
+// DiskImage represents a serialized structure. Marshaling and unmarshaling it gives back the same
+// value: fields omitted when empty decode to their zero value
 type DiskImage struct {
 	ID                  string    `json:"id"`
 	Name                string    `json:"name"`
@@ -26,10 +41,76 @@ type DiskImage struct {
 	DiskImageSizeBytes  int64     `json:"disk_image_size_bytes,omitempty"`
 	LogoURL             string    `json:"logo_url,omitempty"`
 	CreatedAt           time.Time `json:"created_at,omitempty"`
-	CreatedBy           string    `json:"created_by,omitempty"` // User information (because multiple users can operate under the same account)
-	DistributionDefault bool      `json:"distribution_default"`
+	CreatedBy           string    `json:"created_by,omitempty"`           // User information (because multiple users can operate under the same account)
+	DistributionDefault bool      `json:"distribution_default,omitempty"` // Omitted when false, as in CreateDiskImageResponse
+	ImageSHA256         string    `json:"image_sha256,omitempty"`         // Checksum given when the image was created, custom images only
+}
+
+// UnmarshalJSON implements json.Unmarshaler, tolerating an empty, null or non-RFC3339 created_at
+// so one odd timestamp doesn't fail a whole ListDiskImages call. CreatedAt is the zero time when
+// the timestamp can't be parsed
+func (d *DiskImage) UnmarshalJSON(data []byte) error {
+	type diskImage DiskImage
+	aux := struct {
+		*diskImage
+		CreatedAt apiTime `json:"created_at"`
+	}{diskImage: (*diskImage)(d)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	d.CreatedAt = time.Time(aux.CreatedAt)
+	return nil
+}
+
+// IsCustom reports whether this is a custom image uploaded by a user rather than a stock
+// distribution; only custom images carry a disk image URL or checksum
+func (d *DiskImage) IsCustom() bool {
+	return d.DiskImageURL != "" || d.ImageSHA256 != ""
+}
+
+// SizeGB returns the size of the disk image in (decimal) gigabytes, rounded to two decimal places
+func (d *DiskImage) SizeGB() float64 {
+	return roundedSize(d.DiskImageSizeBytes, 1000*1000*1000)
 }
 
+// SizeGiB returns the size of the disk image in (binary) gibibytes, rounded to two decimal places
+func (d *DiskImage) SizeGiB() float64 {
+	return roundedSize(d.DiskImageSizeBytes, 1024*1024*1024)
+}
+
+// roundedSize converts bytes to units of unit bytes, rounded to two decimal places
+func roundedSize(bytes, unit int64) float64 {
+	return math.Round(float64(bytes)/float64(unit)*100) / 100
+}
+
+// DiskImageService is the set of disk image operations, implemented by both Client and FakeClient
+type DiskImageService interface {
+	ListDiskImages(includeCustom ...bool) ([]DiskImage, error)
+	GetDiskImage(id string) (*DiskImage, error)
+	FindDiskImage(search string) (*DiskImage, error)
+	GetDiskImageByName(name string) (*DiskImage, error)
+	GetMostRecentDistro(name string) (*DiskImage, error)
+	CreateDiskImage(params *CreateDiskImageParams) (*CreateDiskImageResponse, error)
+	UpdateDiskImage(id string, params *UpdateDiskImageParams) (*DiskImage, error)
+	DeleteDiskImage(id string) error
+}
+
+var _ DiskImageService = (*Client)(nil)
+
+// DiskImages returns the client as a DiskImageService, for code that only needs disk image operations
+func (c *Client) DiskImages() DiskImageService {
+	return c
+}
+
+const (
+	// DiskImageStateFailed is the terminal state a disk image enters when processing fails
+	DiskImageStateFailed = "failed"
+
+	// DiskImageStateAvailable is the terminal state a disk image enters once it is ready to use
+	DiskImageStateAvailable = "available"
+)
+
 // CreateDiskImageParams represents the parameters for creating a new disk image
 type CreateDiskImageParams struct {
 	Name           string `json:"name"`
@@ -45,6 +126,62 @@ type CreateDiskImageParams struct {
 	ImageSizeBytes int64  `json:"image_size_bytes"` // Size of the image in bytes
 }
 
+// Validate checks the params have everything the API needs to create a disk image
+func (p *CreateDiskImageParams) Validate() error {
+	if p == nil {
+		return ParameterValueMissingError.wrap(errors.New("no params supplied to create a disk image"))
+	}
+
+	required := []struct {
+		name  string
+		value string
+	}{
+		{"name", p.Name},
+		{"distribution", p.Distribution},
+		{"version", p.Version},
+		{"source", p.Source},
+		{"image_sha256", p.ImageSHA256},
+		{"image_md5", p.ImageMD5},
+	}
+	for _, field := range required {
+		if field.value == "" {
+			err := fmt.Errorf("%s is required to create a disk image", field.name)
+			return ParameterValueMissingError.wrap(err)
+		}
+	}
+
+	if p.ImageSizeBytes <= 0 {
+		err := fmt.Errorf("image_size_bytes must be greater than zero, got %d", p.ImageSizeBytes)
+		return ParameterValueMissingError.wrap(err)
+	}
+
+	if !isHexOfLength(p.ImageSHA256, 64) {
+		err := fmt.Errorf("image_sha256 must be 64 hex characters, got %q", p.ImageSHA256)
+		return ParameterChecksumInvalidError.wrap(err)
+	}
+	if !isHexOfLength(p.ImageMD5, 32) {
+		err := fmt.Errorf("image_md5 must be 32 hex characters, got %q", p.ImageMD5)
+		return ParameterChecksumInvalidError.wrap(err)
+	}
+
+	return nil
+}
+
+func isHexOfLength(s string, length int) bool {
+	if len(s) != length {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// UpdateDiskImageParams represents the mutable fields of a disk image, nil fields are left unchanged
+type UpdateDiskImageParams struct {
+	Label       *string `json:"label,omitempty"`
+	Description *string `json:"description,omitempty"`
+	LogoBase64  *string `json:"logo_base64,omitempty"`
+}
+
 // CreateDiskImageResponse represents the response from creating a new disk image
 type CreateDiskImageResponse struct {
 	ID                  string    `json:"id"`
@@ -64,20 +201,96 @@ type CreateDiskImageResponse struct {
 	DistributionDefault bool      `json:"distribution_default,omitempty"`
 }
 
+// SizeGB returns the size of the disk image in (decimal) gigabytes, rounded to two decimal places
+func (r *CreateDiskImageResponse) SizeGB() float64 {
+	return roundedSize(r.DiskImageSizeBytes, 1000*1000*1000)
+}
+
+// SizeGiB returns the size of the disk image in (binary) gibibytes, rounded to two decimal places
+func (r *CreateDiskImageResponse) SizeGiB() float64 {
+	return roundedSize(r.DiskImageSizeBytes, 1024*1024*1024)
+}
+
+// DiskImageListOptions controls which disk images ListDiskImagesWithFilter returns.
+// The zero value matches the default ListDiskImages behaviour.
+type DiskImageListOptions struct {
+	// Region overrides the Client's region for this listing, custom images only exist in the region they were created in
+	Region        string `url:"region,omitempty"`
+	Distribution  string `url:"distribution,omitempty"`
+	IncludeK3s    bool   `url:"include_k3s,omitempty"`
+	IncludeTalos  bool   `url:"include_talos,omitempty"`
+	IncludeCustom bool   `url:"-"`
+}
+
+// PaginatedDiskImages is a page of disk images
+type PaginatedDiskImages struct {
+	Page    int         `json:"page"`
+	PerPage int         `json:"per_page"`
+	Pages   int         `json:"pages"`
+	Items   []DiskImage `json:"items"`
+}
+
 // ListDiskImages return all disk image in system
 // includeCustom when true will also return custom images (default: false)
+// Images with "k3s" or "talos" in their name are left out, as they're for Civo's managed
+// Kubernetes; use ListAllDiskImages, or ListDiskImagesWithFilter with IncludeK3s/IncludeTalos, to get them
 func (c *Client) ListDiskImages(includeCustom ...bool) ([]DiskImage, error) {
-	includeCustomFlag := false
+	return c.ListDiskImagesWithContext(context.Background(), includeCustom...)
+}
+
+// ListDiskImagesWithContext is ListDiskImages bound to ctx
+func (c *Client) ListDiskImagesWithContext(ctx context.Context, includeCustom ...bool) ([]DiskImage, error) {
+	opts := DiskImageListOptions{}
+	if len(includeCustom) > 0 {
+		opts.IncludeCustom = includeCustom[0]
+	}
+
+	return c.ListDiskImagesWithFilterContext(ctx, opts)
+}
+
+// ListAllDiskImages returns every disk image in the Client's region, including the k3s and talos
+// images ListDiskImages leaves out
+func (c *Client) ListAllDiskImages() ([]DiskImage, error) {
+	return c.ListDiskImagesWithFilter(DiskImageListOptions{IncludeK3s: true, IncludeTalos: true})
+}
+
+// ListDiskImagesInRegion returns all disk images in the given region, rather than the Client's region
+func (c *Client) ListDiskImagesInRegion(region string, includeCustom ...bool) ([]DiskImage, error) {
+	opts := DiskImageListOptions{Region: region}
 	if len(includeCustom) > 0 {
-		includeCustomFlag = includeCustom[0]
+		opts.IncludeCustom = includeCustom[0]
+	}
+
+	return c.ListDiskImagesWithFilter(opts)
+}
+
+// ListDiskImagesWithFilter returns the disk images matching opts, the filters are sent
+// to the API as query parameters
+func (c *Client) ListDiskImagesWithFilter(opts DiskImageListOptions) ([]DiskImage, error) {
+	return c.ListDiskImagesWithFilterContext(context.Background(), opts)
+}
+
+// ListDiskImagesWithFilterContext is ListDiskImagesWithFilter bound to ctx
+func (c *Client) ListDiskImagesWithFilterContext(ctx context.Context, opts DiskImageListOptions) ([]DiskImage, error) {
+	vals, err := query.Values(opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.IncludeCustom {
+		vals.Set("type", "custom")
 	}
 
 	url := "/v2/disk_images"
-	if includeCustomFlag {
-		url += "?type=custom"
+	if len(vals) > 0 {
+		url += "?" + vals.Encode()
 	}
 
-	resp, err := c.SendGetRequest(url)
+	cacheKey := c.organisationID + " " + c.Region + " " + c.BaseURL.String() + url
+	if diskImages, ok := c.diskImageCache.get(cacheKey); ok {
+		return filterDiskImages(diskImages, opts), nil
+	}
+
+	resp, err := c.SendGetRequestWithContext(ctx, url)
 	if err != nil {
 		return nil, decodeError(err)
 	}
@@ -86,20 +299,147 @@ func (c *Client) ListDiskImages(includeCustom ...bool) ([]DiskImage, error) {
 	if err := json.NewDecoder(bytes.NewReader(resp)).Decode(&diskImages); err != nil {
 		return nil, err
 	}
+	c.diskImageCache.set(cacheKey, diskImages)
+
+	return filterDiskImages(diskImages, opts), nil
+}
 
+// ListCustomDiskImages returns only the custom images uploaded to the Client's region, whatever
+// their name, leaving out the stock distributions
+func (c *Client) ListCustomDiskImages() ([]DiskImage, error) {
+	diskImages, err := c.ListDiskImagesWithFilter(DiskImageListOptions{IncludeCustom: true, IncludeK3s: true, IncludeTalos: true})
+	if err != nil {
+		return nil, err
+	}
+
+	custom := make([]DiskImage, 0)
+	for _, diskImage := range diskImages {
+		if diskImage.IsCustom() {
+			custom = append(custom, diskImage)
+		}
+	}
+
+	return custom, nil
+}
+
+// ListDiskImagesByOS returns the disk images whose OS matches os, ignoring case (e.g. "linux" or
+// "windows"). The k3s and talos images are left out, as with ListDiskImages
+func (c *Client) ListDiskImagesByOS(os string) ([]DiskImage, error) {
+	diskImages, err := c.ListDiskImages()
+	if err != nil {
+		return nil, err
+	}
+
+	matching := make([]DiskImage, 0)
+	for _, diskImage := range diskImages {
+		if strings.EqualFold(diskImage.OS, os) {
+			matching = append(matching, diskImage)
+		}
+	}
+
+	return matching, nil
+}
+
+// ListDiskImagesByCreator returns the custom disk images created by createdBy, the email or
+// username in each image's CreatedBy, ignoring case. It's for finding one user's images on an
+// account shared by several
+func (c *Client) ListDiskImagesByCreator(createdBy string) ([]DiskImage, error) {
+	diskImages, err := c.ListDiskImages(true)
+	if err != nil {
+		return nil, err
+	}
+
+	matching := make([]DiskImage, 0)
+	for _, diskImage := range diskImages {
+		if strings.EqualFold(diskImage.CreatedBy, createdBy) {
+			matching = append(matching, diskImage)
+		}
+	}
+
+	return matching, nil
+}
+
+// ListDiskImagesCreatedBefore returns the custom disk images created before t, e.g. to find the
+// ones a retention policy should remove. Images without a created_at timestamp (a zero
+// CreatedAt) are left out, as their age isn't known
+func (c *Client) ListDiskImagesCreatedBefore(t time.Time) ([]DiskImage, error) {
+	return c.listDiskImagesCreated(func(createdAt time.Time) bool { return createdAt.Before(t) })
+}
+
+// ListDiskImagesCreatedAfter returns the custom disk images created after t. As with
+// ListDiskImagesCreatedBefore, images without a created_at timestamp are left out
+func (c *Client) ListDiskImagesCreatedAfter(t time.Time) ([]DiskImage, error) {
+	return c.listDiskImagesCreated(func(createdAt time.Time) bool { return createdAt.After(t) })
+}
+
+// listDiskImagesCreated returns the custom disk images with a CreatedAt that match reports true for
+func (c *Client) listDiskImagesCreated(match func(createdAt time.Time) bool) ([]DiskImage, error) {
+	diskImages, err := c.ListDiskImages(true)
+	if err != nil {
+		return nil, err
+	}
+
+	matching := make([]DiskImage, 0)
+	for _, diskImage := range diskImages {
+		if !diskImage.CreatedAt.IsZero() && match(diskImage.CreatedAt) {
+			matching = append(matching, diskImage)
+		}
+	}
+
+	return matching, nil
+}
+
+// PaginatedListDiskImages returns a page of disk images, with the same k3s/talos
+// filtering as ListDiskImages applied to the page
+func (c *Client) PaginatedListDiskImages(page, perPage int) (*PaginatedDiskImages, error) {
+	return c.PaginatedListDiskImagesWithContext(context.Background(), page, perPage)
+}
+
+// PaginatedListDiskImagesWithContext is PaginatedListDiskImages bound to ctx
+func (c *Client) PaginatedListDiskImagesWithContext(ctx context.Context, page, perPage int) (*PaginatedDiskImages, error) {
+	resp, err := c.SendGetRequestWithContext(ctx, fmt.Sprintf("/v2/disk_images?page=%d&per_page=%d", page, perPage))
+	if err != nil {
+		return nil, decodeError(err)
+	}
+
+	diskImages := &PaginatedDiskImages{}
+	if err := json.NewDecoder(bytes.NewReader(resp)).Decode(diskImages); err != nil {
+		return nil, err
+	}
+	diskImages.Items = filterDiskImages(diskImages.Items, DiskImageListOptions{})
+
+	return diskImages, nil
+}
+
+// filterDiskImages drops the k3s and talos images unless opts asks for them, in case the
+// API doesn't apply the filter itself
+func filterDiskImages(diskImages []DiskImage, opts DiskImageListOptions) []DiskImage {
 	filteredDiskImages := make([]DiskImage, 0)
 	for _, diskImage := range diskImages {
-		if !strings.Contains(diskImage.Name, "k3s") && !strings.Contains(diskImage.Name, "talos") {
-			filteredDiskImages = append(filteredDiskImages, diskImage)
+		if !opts.IncludeK3s && strings.Contains(diskImage.Name, "k3s") {
+			continue
+		}
+		if !opts.IncludeTalos && strings.Contains(diskImage.Name, "talos") {
+			continue
 		}
+		filteredDiskImages = append(filteredDiskImages, diskImage)
 	}
 
-	return filteredDiskImages, nil
+	return filteredDiskImages
 }
 
 // GetDiskImage get one disk image using the id
 func (c *Client) GetDiskImage(id string) (*DiskImage, error) {
-	resp, err := c.SendGetRequest(fmt.Sprintf("/v2/disk_images/%s", id))
+	return c.GetDiskImageWithContext(context.Background(), id)
+}
+
+// GetDiskImageWithContext is GetDiskImage bound to ctx
+func (c *Client) GetDiskImageWithContext(ctx context.Context, id string) (*DiskImage, error) {
+	return c.getDiskImage(ctx, fmt.Sprintf("/v2/disk_images/%s", id))
+}
+
+func (c *Client) getDiskImage(ctx context.Context, requestURL string) (*DiskImage, error) {
+	resp, err := c.SendGetRequestWithContext(ctx, requestURL)
 	if err != nil {
 		return nil, decodeError(err)
 	}
@@ -112,43 +452,50 @@ func (c *Client) GetDiskImage(id string) (*DiskImage, error) {
 	return diskImage, nil
 }
 
+// GetDiskImageInRegion gets one disk image using the id from the given region, rather than the Client's region
+func (c *Client) GetDiskImageInRegion(id, region string) (*DiskImage, error) {
+	return c.getDiskImage(context.Background(), fmt.Sprintf("/v2/disk_images/%s?region=%s", id, url.QueryEscape(region)))
+}
+
 // FindDiskImage finds a disk image by either part of the ID or part of the name
 func (c *Client) FindDiskImage(search string) (*DiskImage, error) {
-	templateList, err := c.ListDiskImages()
+	return c.FindDiskImageWithContext(context.Background(), search)
+}
+
+// FindDiskImageWithContext is FindDiskImage bound to ctx
+func (c *Client) FindDiskImageWithContext(ctx context.Context, search string) (*DiskImage, error) {
+	templateList, err := c.ListDiskImagesWithContext(ctx)
 	if err != nil {
 		return nil, decodeError(err)
 	}
 
-	exactMatch := false
-	partialMatchesCount := 0
-	result := DiskImage{}
+	return findMatch(templateList, search, func(d DiskImage) (string, string) { return d.ID, d.Name })
+}
 
-	for _, value := range templateList {
-		if value.Name == search || value.ID == search {
-			exactMatch = true
-			result = value
-		} else if strings.Contains(value.Name, search) || strings.Contains(value.ID, search) {
-			if !exactMatch {
-				result = value
-				partialMatchesCount++
-			}
-		}
+// DiskImageExists reports whether FindDiskImage finds an image whose ID or name is exactly
+// idOrName, for create-if-missing logic. Not finding one isn't an error, and neither is a search
+// that only partly matches images (which FindDiskImage would return); the error is kept for
+// failing to list the images
+func (c *Client) DiskImageExists(idOrName string) (bool, error) {
+	diskImage, err := c.FindDiskImage(idOrName)
+	if errors.Is(err, ZeroMatchesError) || errors.Is(err, MultipleMatchesError) {
+		return false, nil
 	}
-
-	if exactMatch || partialMatchesCount == 1 {
-		return &result, nil
-	} else if partialMatchesCount > 1 {
-		err := fmt.Errorf("unable to find %s because there were multiple matches", search)
-		return nil, MultipleMatchesError.wrap(err)
-	} else {
-		err := fmt.Errorf("unable to find %s, zero matches", search)
-		return nil, ZeroMatchesError.wrap(err)
+	if err != nil {
+		return false, err
 	}
+
+	return diskImage.ID == idOrName || diskImage.Name == idOrName, nil
 }
 
 // GetDiskImageByName finds the DiskImage for an account with the specified code
 func (c *Client) GetDiskImageByName(name string) (*DiskImage, error) {
-	resp, err := c.ListDiskImages()
+	return c.GetDiskImageByNameWithContext(context.Background(), name)
+}
+
+// GetDiskImageByNameWithContext is GetDiskImageByName bound to ctx
+func (c *Client) GetDiskImageByNameWithContext(ctx context.Context, name string) (*DiskImage, error) {
+	resp, err := c.ListDiskImagesWithContext(ctx)
 	if err != nil {
 		return nil, decodeError(err)
 	}
@@ -159,26 +506,85 @@ func (c *Client) GetDiskImageByName(name string) (*DiskImage, error) {
 		}
 	}
 
-	return nil, errors.New("diskimage not found")
+	err = fmt.Errorf("unable to find disk image %s, zero matches", name)
+	return nil, ZeroMatchesError.wrap(err)
+}
+
+// GetDiskImageBySHA256 finds a custom disk image created with the given (hex encoded) SHA256
+// checksum, so an image can be checked for before uploading it again. If several images share
+// the checksum the first is returned; if none do it returns a ZeroMatchesError
+func (c *Client) GetDiskImageBySHA256(sha256 string) (*DiskImage, error) {
+	if !isHexOfLength(sha256, 64) {
+		err := fmt.Errorf("sha256 must be 64 hex characters, got %q", sha256)
+		return nil, ParameterChecksumInvalidError.wrap(err)
+	}
+
+	resp, err := c.ListDiskImages(true)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, diskimage := range resp {
+		if strings.EqualFold(diskimage.ImageSHA256, sha256) {
+			return &resp[i], nil
+		}
+	}
+
+	err = fmt.Errorf("unable to find disk image with sha256 %s, zero matches", sha256)
+	return nil, ZeroMatchesError.wrap(err)
+}
+
+// GetDiskImageByLabel finds the single DiskImage with the specified label (e.g. "ubuntu-jammy"),
+// which unlike the name doesn't change between image versions. It returns a ZeroMatchesError if
+// no image has the label, or a MultipleMatchesError if more than one does
+func (c *Client) GetDiskImageByLabel(label string) (*DiskImage, error) {
+	return c.GetDiskImageByLabelWithContext(context.Background(), label)
+}
+
+// GetDiskImageByLabelWithContext is GetDiskImageByLabel bound to ctx
+func (c *Client) GetDiskImageByLabelWithContext(ctx context.Context, label string) (*DiskImage, error) {
+	resp, err := c.ListDiskImagesWithContext(ctx)
+	if err != nil {
+		return nil, decodeError(err)
+	}
+
+	var result *DiskImage
+	for i, diskimage := range resp {
+		if diskimage.Label != label {
+			continue
+		}
+		if result != nil {
+			err := fmt.Errorf("unable to find disk image with label %s because there were multiple matches", label)
+			return nil, MultipleMatchesError.wrap(err)
+		}
+		result = &resp[i]
+	}
+
+	if result == nil {
+		err := fmt.Errorf("unable to find disk image with label %s, zero matches", label)
+		return nil, ZeroMatchesError.wrap(err)
+	}
+	return result, nil
 }
 
 // GetMostRecentDistro finds the highest version of a specified distro
 func (c *Client) GetMostRecentDistro(name string) (*DiskImage, error) {
-	resp, err := c.ListDiskImages()
+	return c.GetMostRecentDistroWithContext(context.Background(), name)
+}
+
+// GetMostRecentDistroWithContext is GetMostRecentDistro bound to ctx
+func (c *Client) GetMostRecentDistroWithContext(ctx context.Context, name string) (*DiskImage, error) {
+	resp, err := c.ListDiskImagesWithContext(ctx)
 	if err != nil {
 		return nil, decodeError(err)
 	}
 
 	var highestVersionDistro *DiskImage
 
-	for _, diskimage := range resp {
+	for k, diskimage := range resp {
 		if strings.Contains(diskimage.Name, name) {
-			if highestVersionDistro == nil {
-				highestVersionDistro = &diskimage
-			} else {
-				if semver.Compare(highestVersionDistro.Version, diskimage.Version) < 0 {
-					highestVersionDistro = &diskimage
-				}
+			if highestVersionDistro == nil || compareDistroVersions(highestVersionDistro.Version, diskimage.Version) < 0 {
+				highestVersionDistro = &resp[k]
 			}
 		}
 	}
@@ -189,10 +595,138 @@ func (c *Client) GetMostRecentDistro(name string) (*DiskImage, error) {
 	return highestVersionDistro, nil
 }
 
+// compareDistroVersions returns -1, 0 or 1 as a is lower, equal or higher than b. Versions are
+// compared as semver where both are valid (with a "v" prefix added if missing), otherwise each
+// dot separated part is compared numerically if it can be, or lexically if not (e.g. "jammy")
+func compareDistroVersions(a, b string) int {
+	va, vb := a, b
+	if !strings.HasPrefix(va, "v") {
+		va = "v" + va
+	}
+	if !strings.HasPrefix(vb, "v") {
+		vb = "v" + vb
+	}
+	if semver.IsValid(va) && semver.IsValid(vb) {
+		return semver.Compare(va, vb)
+	}
+
+	partsA := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	partsB := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		if i >= len(partsA) {
+			return -1
+		}
+		if i >= len(partsB) {
+			return 1
+		}
+
+		numA, errA := strconv.Atoi(partsA[i])
+		numB, errB := strconv.Atoi(partsB[i])
+		switch {
+		case errA == nil && errB == nil:
+			if numA != numB {
+				if numA < numB {
+					return -1
+				}
+				return 1
+			}
+		case partsA[i] != partsB[i]:
+			return strings.Compare(partsA[i], partsB[i])
+		}
+	}
+
+	return 0
+}
+
+// diskImageMaxPollInterval is the longest WaitForDiskImageState backs off to between checks
+const diskImageMaxPollInterval = time.Minute
+
+// WaitForDiskImageState polls the disk image until its State is targetState, returning the
+// image once it is. It gives up after timeout, or as soon as the image reaches the failed state.
+// Images can take a long time to process, so the wait between checks starts at pollInterval (5
+// seconds by default) and doubles after each check, up to a minute
+func (c *Client) WaitForDiskImageState(id, targetState string, timeout time.Duration, pollInterval ...time.Duration) (*DiskImage, error) {
+	return c.WaitForDiskImageStateWithContext(context.Background(), id, targetState, timeout, pollInterval...)
+}
+
+// WaitForDiskImageStateWithContext is WaitForDiskImageState bound to ctx
+func (c *Client) WaitForDiskImageStateWithContext(ctx context.Context, id, targetState string, timeout time.Duration, pollInterval ...time.Duration) (*DiskImage, error) {
+	var diskImage *DiskImage
+	interval := pollIntervalOrDefault(pollInterval)
+	maxInterval := diskImageMaxPollInterval
+	if interval > maxInterval {
+		maxInterval = interval
+	}
+
+	err := pollWithBackoff(ctx, timeout, interval, maxInterval, fmt.Sprintf("disk image %s to be %s", id, targetState), func(ctx context.Context) (bool, error) {
+		var err error
+		diskImage, err = c.GetDiskImageWithContext(ctx, id)
+		if err != nil {
+			return false, err
+		}
+
+		if diskImage.State == DiskImageStateFailed && targetState != DiskImageStateFailed {
+			err := fmt.Errorf("disk image %s is in the %s state", id, diskImage.State)
+			return false, DiskImageFailedError.wrap(err)
+		}
+		return diskImage.State == targetState, nil
+	})
+	if err != nil {
+		if errors.Is(err, DiskImageFailedError) {
+			return diskImage, err
+		}
+		return nil, err
+	}
+
+	return diskImage, nil
+}
+
+// GetDistributionDefault finds the disk image the API marks as the default for a distribution
+func (c *Client) GetDistributionDefault(distribution string) (*DiskImage, error) {
+	return c.GetDistributionDefaultWithContext(context.Background(), distribution)
+}
+
+// GetDistributionDefaultWithContext is GetDistributionDefault bound to ctx
+func (c *Client) GetDistributionDefaultWithContext(ctx context.Context, distribution string) (*DiskImage, error) {
+	resp, err := c.ListDiskImagesWithContext(ctx)
+	if err != nil {
+		return nil, decodeError(err)
+	}
+
+	var result *DiskImage
+	for k, diskimage := range resp {
+		if diskimage.Distribution != distribution || !diskimage.DistributionDefault {
+			continue
+		}
+		if result != nil {
+			err := fmt.Errorf("unable to find the default %s image because there were multiple matches", distribution)
+			return nil, MultipleMatchesError.wrap(err)
+		}
+		result = &resp[k]
+	}
+
+	if result == nil {
+		err := fmt.Errorf("unable to find the default %s image, zero matches", distribution)
+		return nil, ZeroMatchesError.wrap(err)
+	}
+
+	return result, nil
+}
+
 // CreateDiskImage creates a new disk image entry and returns a pre-signed URL for uploading
 func (c *Client) CreateDiskImage(params *CreateDiskImageParams) (*CreateDiskImageResponse, error) {
+	return c.CreateDiskImageWithContext(context.Background(), params)
+}
+
+// CreateDiskImageWithContext is CreateDiskImage bound to ctx
+func (c *Client) CreateDiskImageWithContext(ctx context.Context, params *CreateDiskImageParams) (*CreateDiskImageResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
 	url := "/v2/disk_images"
-	resp, err := c.SendPostRequest(url, params)
+	resp, err := c.SendPostRequestWithContext(ctx, url, params)
+	c.ClearCache()
 
 	if err != nil {
 		return nil, decodeError(err)
@@ -206,12 +740,214 @@ func (c *Client) CreateDiskImage(params *CreateDiskImageParams) (*CreateDiskImag
 	return diskImage, nil
 }
 
+// UpdateDiskImage updates the label, description and/or logo of a disk image
+func (c *Client) UpdateDiskImage(id string, params *UpdateDiskImageParams) (*DiskImage, error) {
+	return c.UpdateDiskImageWithContext(context.Background(), id, params)
+}
+
+// UpdateDiskImageWithContext is UpdateDiskImage bound to ctx
+func (c *Client) UpdateDiskImageWithContext(ctx context.Context, id string, params *UpdateDiskImageParams) (*DiskImage, error) {
+	resp, err := c.SendPutRequestWithContext(ctx, fmt.Sprintf("/v2/disk_images/%s", id), params)
+	c.ClearCache()
+	if err != nil {
+		return nil, decodeError(err)
+	}
+
+	diskImage := &DiskImage{}
+	if err := json.NewDecoder(bytes.NewReader(resp)).Decode(diskImage); err != nil {
+		return nil, err
+	}
+
+	return diskImage, nil
+}
+
+// UploadDiskImage streams the image from r to the pre-signed uploadURL returned by CreateDiskImage.
+// md5sum and sha256sum are the hex encoded checksums that were passed to CreateDiskImage. The upload
+// goes straight to the storage backend, so it doesn't carry the API credentials
+func (c *Client) UploadDiskImage(uploadURL string, r io.Reader, size int64, md5sum, sha256sum string) error {
+	return c.UploadDiskImageWithContext(context.Background(), uploadURL, r, size, md5sum, sha256sum)
+}
+
+// UploadDiskImageWithContext is UploadDiskImage bound to ctx
+func (c *Client) UploadDiskImageWithContext(ctx context.Context, uploadURL string, r io.Reader, size int64, md5sum, sha256sum string) error {
+	md5Bytes, err := hex.DecodeString(md5sum)
+	if err != nil {
+		return ParameterChecksumInvalidError.wrap(fmt.Errorf("md5sum is not hex encoded: %w", err))
+	}
+	sha256Bytes, err := hex.DecodeString(sha256sum)
+	if err != nil {
+		return ParameterChecksumInvalidError.wrap(fmt.Errorf("sha256sum is not hex encoded: %w", err))
+	}
+
+	ctx, cancel := c.withUploadTimeout(ctx)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", uploadURL, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Bytes))
+	req.Header.Set("x-amz-checksum-sha256", base64.StdEncoding.EncodeToString(sha256Bytes))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return decodeError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return DiskImageUploadFailedError.wrap(HTTPError{Code: resp.StatusCode, Status: resp.Status, Reason: string(body)})
+	}
+
+	return nil
+}
+
+// UploadProgressFunc is told how many bytes of an upload have been sent so far, out of totalBytes
+type UploadProgressFunc func(bytesSent, totalBytes int64)
+
+// UploadDiskImageWithProgress is UploadDiskImage, calling progress as the image is read from r so
+// a progress bar can be shown. progress is called from the goroutine sending the request
+func (c *Client) UploadDiskImageWithProgress(uploadURL string, r io.Reader, size int64, md5sum, sha256sum string, progress UploadProgressFunc) error {
+	return c.UploadDiskImageWithProgressContext(context.Background(), uploadURL, r, size, md5sum, sha256sum, progress)
+}
+
+// UploadDiskImageWithProgressContext is UploadDiskImageWithProgress bound to ctx
+func (c *Client) UploadDiskImageWithProgressContext(ctx context.Context, uploadURL string, r io.Reader, size int64, md5sum, sha256sum string, progress UploadProgressFunc) error {
+	if progress != nil {
+		r = &progressReader{r: r, total: size, progress: progress}
+	}
+	return c.UploadDiskImageWithContext(ctx, uploadURL, r, size, md5sum, sha256sum)
+}
+
+// progressReader reports how much has been read through it to progress after every read
+type progressReader struct {
+	r        io.Reader
+	sent     int64
+	total    int64
+	progress UploadProgressFunc
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.sent += int64(n)
+		p.progress(p.sent, p.total)
+	}
+	return n, err
+}
+
+// CancelDiskImage aborts the upload/processing of a disk image that hasn't finished yet and
+// tears it down. Images that are already available or failed can't be cancelled, use
+// DeleteDiskImage for those
+func (c *Client) CancelDiskImage(id string) error {
+	return c.CancelDiskImageWithContext(context.Background(), id)
+}
+
+// CancelDiskImageWithContext is CancelDiskImage bound to ctx
+func (c *Client) CancelDiskImageWithContext(ctx context.Context, id string) error {
+	diskImage, err := c.GetDiskImageWithContext(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if diskImage.State == DiskImageStateAvailable || diskImage.State == DiskImageStateFailed {
+		err := fmt.Errorf("disk image %s can't be cancelled because it is already %s", id, diskImage.State)
+		return DiskImageTerminalStateError.wrap(err)
+	}
+
+	_, err = c.SendDeleteRequestWithContext(ctx, fmt.Sprintf("/v2/disk_images/%s?cancel=true", id))
+	c.ClearCache()
+	if err != nil {
+		return decodeError(err)
+	}
+
+	return nil
+}
+
+// DiskImageDeleteResult is the outcome of deleting one disk image as part of DeleteDiskImages
+type DiskImageDeleteResult struct {
+	ID      string
+	Deleted bool
+	Err     error
+}
+
+// DeleteDiskImages deletes each of the disk images, returning a result per ID in the same order.
+// A failure to delete one image doesn't stop the others, the error is only non-nil if the batch
+// couldn't be started
+func (c *Client) DeleteDiskImages(ids []string) ([]DiskImageDeleteResult, error) {
+	return c.DeleteDiskImagesWithContext(context.Background(), ids)
+}
+
+// DeleteDiskImagesWithContext is DeleteDiskImages bound to ctx
+func (c *Client) DeleteDiskImagesWithContext(ctx context.Context, ids []string) ([]DiskImageDeleteResult, error) {
+	for _, id := range ids {
+		if id == "" {
+			err := fmt.Errorf("ID is empty")
+			return nil, IDisEmptyError.wrap(err)
+		}
+	}
+
+	results := make([]DiskImageDeleteResult, len(ids))
+	forEachConcurrently(len(ids), func(i int) {
+		err := c.DeleteDiskImageWithContext(ctx, ids[i])
+		results[i] = DiskImageDeleteResult{ID: ids[i], Deleted: err == nil, Err: err}
+	})
+
+	return results, nil
+}
+
 // DeleteDiskImage deletes a disk image by its ID
 func (c *Client) DeleteDiskImage(id string) error {
-	_, err := c.SendDeleteRequest(fmt.Sprintf("/v2/disk_images/%s", id))
+	return c.DeleteDiskImageWithContext(context.Background(), id)
+}
+
+// DeleteDiskImageWithContext is DeleteDiskImage bound to ctx
+func (c *Client) DeleteDiskImageWithContext(ctx context.Context, id string) error {
+	_, err := c.SendDeleteRequestWithContext(ctx, fmt.Sprintf("/v2/disk_images/%s", id))
+	c.ClearCache()
 	if err != nil {
 		return decodeError(err)
 	}
 
 	return nil
 }
+
+// DeleteDiskImageAndWait deletes a disk image and waits, for up to timeout, until GetDiskImage no
+// longer finds it, as images linger in a "deleting" state for a while and creating another image
+// with the same name before then can collide. An image that's already gone counts as deleted.
+// pollInterval optionally overrides the default of 5 seconds between checks
+func (c *Client) DeleteDiskImageAndWait(id string, timeout time.Duration, pollInterval ...time.Duration) error {
+	return c.DeleteDiskImageAndWaitWithContext(context.Background(), id, timeout, pollInterval...)
+}
+
+// DeleteDiskImageAndWaitWithContext is DeleteDiskImageAndWait bound to ctx
+func (c *Client) DeleteDiskImageAndWaitWithContext(ctx context.Context, id string, timeout time.Duration, pollInterval ...time.Duration) error {
+	if id == "" {
+		err := fmt.Errorf("ID is empty")
+		return IDisEmptyError.wrap(err)
+	}
+
+	if err := c.DeleteDiskImageWithContext(ctx, id); err != nil && !isDiskImageNotFound(err) {
+		return err
+	}
+
+	return pollUntil(ctx, timeout, pollIntervalOrDefault(pollInterval), fmt.Sprintf("disk image %s to be deleted", id), func(ctx context.Context) (bool, error) {
+		_, err := c.GetDiskImageWithContext(ctx, id)
+		if err != nil {
+			if isDiskImageNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		return false, nil
+	})
+}
+
+// isDiskImageNotFound reports whether err means the disk image doesn't exist
+func isDiskImageNotFound(err error) bool {
+	var httpErr HTTPError
+	return errors.Is(err, DatabaseDiskImageNotFoundError) || (errors.As(err, &httpErr) && httpErr.Code == http.StatusNotFound)
+}
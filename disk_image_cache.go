@@ -0,0 +1,66 @@
+package civogo
+
+import (
+	"sync"
+	"time"
+)
+
+// diskImageCache holds recent disk image listings, keyed by region and request URL, for a TTL
+type diskImageCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]diskImageCacheEntry
+}
+
+type diskImageCacheEntry struct {
+	images  []DiskImage
+	expires time.Time
+}
+
+// EnableDiskImageCache makes the client reuse the result of listing disk images for ttl, so calls
+// like FindDiskImage and GetMostRecentDistro made close together only list the images once.
+// Creating, updating, cancelling or deleting a disk image through the client clears the cache.
+// A ttl of 0 or less disables the cache
+func (c *Client) EnableDiskImageCache(ttl time.Duration) {
+	if ttl <= 0 {
+		c.diskImageCache = nil
+		return
+	}
+	c.diskImageCache = &diskImageCache{ttl: ttl, entries: map[string]diskImageCacheEntry{}}
+}
+
+// ClearCache empties the disk image cache, if EnableDiskImageCache has turned it on
+func (c *Client) ClearCache() {
+	if c.diskImageCache == nil {
+		return
+	}
+	c.diskImageCache.mu.Lock()
+	defer c.diskImageCache.mu.Unlock()
+	c.diskImageCache.entries = map[string]diskImageCacheEntry{}
+}
+
+// get returns a copy of the images cached for key, if they haven't expired
+func (dc *diskImageCache) get(key string) ([]DiskImage, bool) {
+	if dc == nil {
+		return nil, false
+	}
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	entry, ok := dc.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		delete(dc.entries, key)
+		return nil, false
+	}
+	return append([]DiskImage(nil), entry.images...), true
+}
+
+// set caches a copy of images under key
+func (dc *diskImageCache) set(key string, images []DiskImage) {
+	if dc == nil {
+		return
+	}
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	dc.entries[key] = diskImageCacheEntry{images: append([]DiskImage(nil), images...), expires: time.Now().Add(dc.ttl)}
+}
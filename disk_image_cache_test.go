@@ -0,0 +1,101 @@
+package civogo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDiskImageCache(t *testing.T) {
+	var mu sync.Mutex
+	lists := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if req.Method == "GET" && req.URL.Path == "/v2/disk_images" {
+			lists++
+			rw.Write([]byte(`[{ "id": "1", "name": "ubuntu-focal", "version": "20.04", "distribution": "ubuntu" }, { "id": "2", "name": "ubuntu-jammy", "version": "22.04", "distribution": "ubuntu" }]`))
+			return
+		}
+		rw.Write([]byte(`{"result": "success"}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+	client.EnableDiskImageCache(time.Minute)
+
+	if _, err := client.FindDiskImage("jammy"); err != nil {
+		t.Errorf("Request returned an error: %s", err)
+	}
+	if _, err := client.GetMostRecentDistro("ubuntu"); err != nil {
+		t.Errorf("Request returned an error: %s", err)
+	}
+	if lists != 1 {
+		t.Errorf("Expected %d, got %d", 1, lists)
+	}
+
+	// a different listing isn't served from the cache
+	if _, err := client.ListDiskImagesInRegion("LON1"); err != nil {
+		t.Errorf("Request returned an error: %s", err)
+	}
+	if lists != 2 {
+		t.Errorf("Expected %d, got %d", 2, lists)
+	}
+
+	if err := client.DeleteDiskImage("1"); err != nil {
+		t.Errorf("Request returned an error: %s", err)
+	}
+	if _, err := client.ListDiskImages(); err != nil {
+		t.Errorf("Request returned an error: %s", err)
+	}
+	if lists != 3 {
+		t.Errorf("Expected %d, got %d", 3, lists)
+	}
+
+	client.ClearCache()
+	if _, err := client.ListDiskImages(); err != nil {
+		t.Errorf("Request returned an error: %s", err)
+	}
+	if lists != 4 {
+		t.Errorf("Expected %d, got %d", 4, lists)
+	}
+}
+
+func TestDiskImageCacheExpires(t *testing.T) {
+	lists := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		lists++
+		rw.Write([]byte(`[{ "id": "1", "name": "ubuntu-jammy" }]`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+	client.EnableDiskImageCache(time.Millisecond)
+
+	got, err := client.ListDiskImages()
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	// changing the result doesn't change what's cached
+	got[0].Name = "changed"
+
+	time.Sleep(5 * time.Millisecond)
+	got, err = client.ListDiskImages()
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if lists != 2 || got[0].Name != "ubuntu-jammy" {
+		t.Errorf("Expected the images to be listed again, got %d lists and %+v", lists, got)
+	}
+
+	client.EnableDiskImageCache(0)
+	client.ClearCache()
+	if _, err := client.ListDiskImages(); err != nil {
+		t.Errorf("Request returned an error: %s", err)
+	}
+	if lists != 3 {
+		t.Errorf("Expected %d, got %d", 3, lists)
+	}
+}
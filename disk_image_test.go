@@ -1,7 +1,13 @@
 package civogo
 
 import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -14,6 +20,15 @@ func TestClienterDiskImage(t *testing.T) {
 	_, _ = c.ListDiskImages()
 }
 
+func TestDiskImageService(t *testing.T) {
+	var s DiskImageService
+
+	client, _ := NewClient("foo", "NYC1")
+	s = client.DiskImages()
+	s, _ = NewFakeClient()
+	_, _ = s.ListDiskImages()
+}
+
 func TestGetDiskImage(t *testing.T) {
 	client, server, _ := NewClientForTesting(map[string]string{
 		"/v2/disk_images/b82168fe-66f6-4b38-a3b8-5283542d5475": `{
@@ -202,6 +217,256 @@ func TestGetDiskImageByName(t *testing.T) {
 	}
 }
 
+func TestGetDiskImageByNameNotFound(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/disk_images": `[{ "ID": "329d473e-f110-4852-b2fa-fe65aa6bff4a", "Name": "ubuntu-bionic", "Version": "18.04", "State": "available", "Distribution": "ubuntu", "Description": "", "Label": "bionic" }]`,
+	})
+	defer server.Close()
+
+	_, err := client.GetDiskImageByName("ubuntu-jammy")
+	if !errors.Is(err, ZeroMatchesError) {
+		t.Errorf("Expected %v, got %v", ZeroMatchesError, err)
+	}
+	if !strings.Contains(err.Error(), "ubuntu-jammy") {
+		t.Errorf("Expected error to mention %s, got %s", "ubuntu-jammy", err)
+	}
+}
+
+func TestListAllDiskImages(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		query = req.URL.RawQuery
+		rw.Write([]byte(`[{ "id": "1", "name": "ubuntu-jammy" }, { "id": "2", "name": "k3s-ubuntu" }, { "id": "3", "name": "talos-v1.5" }]`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.ListAllDiskImages()
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(got) != 3 {
+		t.Errorf("Expected %d, got %d", 3, len(got))
+	}
+	if !strings.Contains(query, "include_k3s=true") || !strings.Contains(query, "include_talos=true") {
+		t.Errorf("Expected the k3s and talos images to be requested, got %s", query)
+	}
+
+	got, err = client.ListDiskImages()
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(got) != 1 {
+		t.Errorf("Expected %d, got %d", 1, len(got))
+	}
+}
+
+func TestDiskImageExists(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/disk_images": `[{ "id": "1", "name": "ubuntu-jammy" }, { "id": "2", "name": "ubuntu-focal" }, { "id": "3", "name": "debian-12" }]`,
+	})
+	defer server.Close()
+
+	tests := []struct {
+		search   string
+		expected bool
+	}{
+		{"ubuntu-jammy", true},
+		{"3", true},
+		{"debian", false},
+		{"ubuntu", false},
+		{"rocky-9", false},
+	}
+	for _, test := range tests {
+		got, err := client.DiskImageExists(test.search)
+		if err != nil {
+			t.Errorf("Request returned an error: %s", err)
+			continue
+		}
+		if got != test.expected {
+			t.Errorf("Expected %s to be %t, got %t", test.search, test.expected, got)
+		}
+	}
+}
+
+func TestDiskImageExistsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+		rw.Write([]byte(`{"code":"unknown_error","reason":"something went wrong"}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	if _, err := client.DiskImageExists("ubuntu-jammy"); err == nil {
+		t.Errorf("Expected an error, got nil")
+	}
+}
+
+func TestListDiskImagesByOS(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/disk_images": `[{ "id": "1", "name": "ubuntu-jammy", "os": "linux" }, { "id": "2", "name": "windows-2022", "os": "Windows" }, { "id": "3", "name": "k3s-ubuntu", "os": "linux" }, { "id": "4", "name": "debian-12", "os": "Linux" }]`,
+	})
+	defer server.Close()
+
+	got, err := client.ListDiskImagesByOS("linux")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(got) != 2 || got[0].ID != "1" || got[1].ID != "4" {
+		t.Errorf("Expected images 1 and 4, got %+v", got)
+	}
+
+	got, err = client.ListDiskImagesByOS("WINDOWS")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(got) != 1 || got[0].ID != "2" {
+		t.Errorf("Expected image 2, got %+v", got)
+	}
+}
+
+func TestListCustomDiskImages(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		query = req.URL.RawQuery
+		rw.Write([]byte(`[{ "id": "1", "name": "ubuntu-jammy" }, { "id": "2", "name": "my-k3s-node", "disk_image_url": "https://example.com/image.raw" }, { "id": "3", "name": "custom-b", "image_sha256": "6105d6cc76af400325e94d588ce511be5bfdbb73b437dc51eca43917d7a43e3d" }]`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.ListCustomDiskImages()
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if !strings.Contains(query, "type=custom") {
+		t.Errorf("Expected custom images to be requested, got query %q", query)
+	}
+	if len(got) != 2 || got[0].ID != "2" || got[1].ID != "3" {
+		t.Errorf("Expected images 2 and 3, got %+v", got)
+	}
+}
+
+func TestListDiskImagesByCreator(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		query = req.URL.RawQuery
+		rw.Write([]byte(`[{ "id": "1", "name": "custom-a", "created_by": "jane@example.com" }, { "id": "2", "name": "custom-b", "created_by": "john@example.com" }, { "id": "3", "name": "custom-c", "created_by": "Jane@Example.com" }, { "id": "4", "name": "ubuntu-jammy" }]`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.ListDiskImagesByCreator("JANE@example.com")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if !strings.Contains(query, "type=custom") {
+		t.Errorf("Expected custom images to be requested, got query %q", query)
+	}
+	if len(got) != 2 || got[0].ID != "1" || got[1].ID != "3" {
+		t.Errorf("Expected images 1 and 3, got %+v", got)
+	}
+
+	got, err = client.ListDiskImagesByCreator("nobody@example.com")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(got) != 0 {
+		t.Errorf("Expected no images, got %+v", got)
+	}
+}
+
+func TestListDiskImagesCreatedBeforeAndAfter(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/disk_images": `[{ "id": "1", "name": "custom-a", "created_at": "2026-01-10T09:00:00Z" }, { "id": "2", "name": "custom-b", "created_at": "2026-06-01T09:00:00Z" }, { "id": "3", "name": "custom-c" }, { "id": "4", "name": "custom-d", "created_at": "2026-09-20 12:00:00 +0000" }]`,
+	})
+	defer server.Close()
+
+	cutoff := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	got, err := client.ListDiskImagesCreatedBefore(cutoff)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(got) != 1 || got[0].ID != "1" {
+		t.Errorf("Expected image 1, got %+v", got)
+	}
+
+	got, err = client.ListDiskImagesCreatedAfter(cutoff)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(got) != 2 || got[0].ID != "2" || got[1].ID != "4" {
+		t.Errorf("Expected images 2 and 4, got %+v", got)
+	}
+}
+
+func TestGetDiskImageBySHA256(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		query = req.URL.RawQuery
+		rw.Write([]byte(`[{ "id": "1", "name": "custom-a", "image_sha256": "6105D6CC76AF400325E94D588CE511BE5BFDBB73B437DC51ECA43917D7A43E3D" }, { "id": "2", "name": "custom-b" }]`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.GetDiskImageBySHA256("6105d6cc76af400325e94d588ce511be5bfdbb73b437dc51eca43917d7a43e3d")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.ID != "1" {
+		t.Errorf("Expected %s, got %s", "1", got.ID)
+	}
+	if !strings.Contains(query, "type=custom") {
+		t.Errorf("Expected custom images to be listed, got %s", query)
+	}
+
+	_, err = client.GetDiskImageBySHA256("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+	if !errors.Is(err, ZeroMatchesError) {
+		t.Errorf("Expected %v, got %v", ZeroMatchesError, err)
+	}
+
+	_, err = client.GetDiskImageBySHA256("not-a-checksum")
+	if !errors.Is(err, ParameterChecksumInvalidError) {
+		t.Errorf("Expected %v, got %v", ParameterChecksumInvalidError, err)
+	}
+}
+
+func TestGetDiskImageByLabel(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/disk_images": `[{ "ID": "329d473e-f110-4852-b2fa-fe65aa6bff4a", "Name": "ubuntu-bionic", "Version": "18.04", "State": "available", "Distribution": "ubuntu", "Description": "", "Label": "bionic" }, { "ID": "77bea4dd-bfd4-492c-823d-f92eb6dd962d", "Name": "ubuntu-focal", "Version": "20.04", "State": "available", "Distribution": "ubuntu", "Description": "", "Label": "focal" }, { "ID": "a4204155-a876-43fa-b4d6-ea2af8774560", "Name": "ubuntu-focal-custom", "Version": "20.04", "State": "available", "Distribution": "ubuntu", "Description": "", "Label": "focal" }]`,
+	})
+	defer server.Close()
+
+	got, err := client.GetDiskImageByLabel("bionic")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.ID != "329d473e-f110-4852-b2fa-fe65aa6bff4a" {
+		t.Errorf("Expected %s, got %s", "329d473e-f110-4852-b2fa-fe65aa6bff4a", got.ID)
+	}
+
+	_, err = client.GetDiskImageByLabel("focal")
+	if !errors.Is(err, MultipleMatchesError) {
+		t.Errorf("Expected %v, got %v", MultipleMatchesError, err)
+	}
+
+	_, err = client.GetDiskImageByLabel("jammy")
+	if !errors.Is(err, ZeroMatchesError) {
+		t.Errorf("Expected %v, got %v", ZeroMatchesError, err)
+	}
+}
+
 func TestGetMostRecentDistro(t *testing.T) {
 	client, server, _ := NewClientForTesting(map[string]string{
 		"/v2/disk_images": `[{ "ID": "329d473e-f110-4852-b2fa-fe65aa6bff4a", "Name": "ubuntu-bionic", "Version": "18.04", "State": "available", "Distribution": "ubuntu", "Description": "", "Label": "bionic" }, { "ID": "77bea4dd-bfd4-492c-823d-f92eb6dd962d", "Name": "ubuntu-focal", "Version": "20.04", "State": "available", "Distribution": "ubuntu", "Description": "", "Label": "focal" }]`,
@@ -218,3 +483,582 @@ func TestGetMostRecentDistro(t *testing.T) {
 		t.Errorf("Expected %s, got %s", "ubuntu-focal", got.Name)
 	}
 }
+
+func TestListDiskImagesWithCancelledContext(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/disk_images": `[]`,
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.ListDiskImagesWithContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+	if !errors.Is(err, RequestCanceledError) {
+		t.Errorf("Expected %v, got %v", RequestCanceledError, err)
+	}
+}
+
+func TestListDiskImagesWithFilter(t *testing.T) {
+	var requestedURLs []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requestedURLs = append(requestedURLs, req.URL.RequestURI())
+		rw.Write([]byte(`[{"id":"1","name":"ubuntu-jammy"},{"id":"2","name":"k3s-1.27"},{"id":"3","name":"talos-v1.5"}]`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.ListDiskImagesWithFilter(DiskImageListOptions{})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(got) != 1 || got[0].Name != "ubuntu-jammy" {
+		t.Errorf("Expected only ubuntu-jammy, got %+v", got)
+	}
+
+	got, err = client.ListDiskImagesWithFilter(DiskImageListOptions{Distribution: "ubuntu", IncludeK3s: true, IncludeCustom: true})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(got) != 2 {
+		t.Errorf("Expected %d, got %d", 2, len(got))
+	}
+
+	_, _ = client.ListDiskImages()
+
+	expected := []string{
+		"/v2/disk_images?region=TEST",
+		"/v2/disk_images?distribution=ubuntu&include_k3s=true&region=TEST&type=custom",
+		"/v2/disk_images?region=TEST",
+	}
+	if !reflect.DeepEqual(requestedURLs, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, requestedURLs)
+	}
+}
+
+func TestPaginatedListDiskImages(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/disk_images?page=2&per_page=3": `{"page":2,"per_page":3,"pages":4,"items":[{"id":"1","name":"ubuntu-jammy"},{"id":"2","name":"k3s-1.27"},{"id":"3","name":"debian-11"}]}`,
+	})
+	defer server.Close()
+
+	got, err := client.PaginatedListDiskImages(2, 3)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+
+	expected := &PaginatedDiskImages{
+		Page:    2,
+		PerPage: 3,
+		Pages:   4,
+		Items: []DiskImage{
+			{ID: "1", Name: "ubuntu-jammy"},
+			{ID: "3", Name: "debian-11"},
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestWaitForDiskImageState(t *testing.T) {
+	states := []string{"pending", "processing", "available"}
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		state := states[len(states)-1]
+		if calls < len(states) {
+			state = states[calls]
+		}
+		calls++
+		rw.Write([]byte(`{"id":"12345","name":"custom","state":"` + state + `"}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.WaitForDiskImageState("12345", "available", time.Second, time.Millisecond)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.State != "available" {
+		t.Errorf("Expected %s, got %s", "available", got.State)
+	}
+	if calls != 3 {
+		t.Errorf("Expected %d, got %d", 3, calls)
+	}
+}
+
+func TestWaitForDiskImageStateBacksOff(t *testing.T) {
+	var checks []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		checks = append(checks, time.Now())
+		state := "processing"
+		if len(checks) > 4 {
+			state = "available"
+		}
+		rw.Write([]byte(`{"id":"12345","name":"custom","state":"` + state + `"}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	_, err := client.WaitForDiskImageState("12345", "available", time.Second, 5*time.Millisecond)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+
+	// the timer is started before each check is sent, so a gap seen by the server can come up a
+	// little short of the interval, but without backing off they'd all be around 5ms
+	for i, expected := range []time.Duration{5, 10, 20, 40} {
+		if gap := checks[i+1].Sub(checks[i]); gap < expected*time.Millisecond*3/4 {
+			t.Errorf("Expected check %d to wait at least %dms, it waited %s", i+2, expected, gap)
+		}
+	}
+}
+
+func TestWaitForDiskImageStateFailed(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/disk_images/12345": `{"id":"12345","name":"custom","state":"failed"}`,
+	})
+	defer server.Close()
+
+	_, err := client.WaitForDiskImageState("12345", "available", time.Second, time.Millisecond)
+	if !errors.Is(err, DiskImageFailedError) {
+		t.Errorf("Expected %v, got %v", DiskImageFailedError, err)
+	}
+}
+
+func TestWaitForDiskImageStateTimeout(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/disk_images/12345": `{"id":"12345","name":"custom","state":"processing"}`,
+	})
+	defer server.Close()
+
+	_, err := client.WaitForDiskImageState("12345", "available", 20*time.Millisecond, time.Millisecond)
+	if !errors.Is(err, TimeoutError) {
+		t.Errorf("Expected %v, got %v", TimeoutError, err)
+	}
+}
+
+func TestCreateDiskImageParamsValidate(t *testing.T) {
+	valid := CreateDiskImageParams{
+		Name:           "custom-ubuntu",
+		Distribution:   "ubuntu",
+		Version:        "22.04",
+		Source:         "upload",
+		ImageSHA256:    "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		ImageMD5:       "d41d8cd98f00b204e9800998ecf8427e",
+		ImageSizeBytes: 1073741824,
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected valid params, got %s", err)
+	}
+
+	missingName := valid
+	missingName.Name = ""
+	if err := missingName.Validate(); !errors.Is(err, ParameterValueMissingError) {
+		t.Errorf("Expected %v, got %v", ParameterValueMissingError, err)
+	}
+
+	zeroSize := valid
+	zeroSize.ImageSizeBytes = 0
+	if err := zeroSize.Validate(); !errors.Is(err, ParameterValueMissingError) {
+		t.Errorf("Expected %v, got %v", ParameterValueMissingError, err)
+	}
+
+	shortSHA := valid
+	shortSHA.ImageSHA256 = "e3b0c442"
+	if err := shortSHA.Validate(); !errors.Is(err, ParameterChecksumInvalidError) {
+		t.Errorf("Expected %v, got %v", ParameterChecksumInvalidError, err)
+	}
+
+	badMD5 := valid
+	badMD5.ImageMD5 = "zz1d8cd98f00b204e9800998ecf8427e"
+	if err := badMD5.Validate(); !errors.Is(err, ParameterChecksumInvalidError) {
+		t.Errorf("Expected %v, got %v", ParameterChecksumInvalidError, err)
+	}
+}
+
+func TestUpdateDiskImage(t *testing.T) {
+	client, server, _ := NewAdvancedClientForTesting([]ConfigAdvanceClientForTesting{
+		{
+			Method: "PUT",
+			Value: []ValueAdvanceClientForTesting{
+				{
+					RequestBody:  `{"label":"Jammy","description":"Ubuntu 22.04 with our agents"}`,
+					URL:          "/v2/disk_images/12345",
+					ResponseBody: `{"id":"12345","name":"custom-ubuntu","label":"Jammy","description":"Ubuntu 22.04 with our agents"}`,
+				},
+			},
+		},
+	})
+	defer server.Close()
+
+	label := "Jammy"
+	description := "Ubuntu 22.04 with our agents"
+	got, err := client.UpdateDiskImage("12345", &UpdateDiskImageParams{
+		Label:       &label,
+		Description: &description,
+	})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+
+	expected := &DiskImage{
+		ID:          "12345",
+		Name:        "custom-ubuntu",
+		Label:       "Jammy",
+		Description: "Ubuntu 22.04 with our agents",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestUploadDiskImageWithProgress(t *testing.T) {
+	var gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		gotBody = string(body)
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	image := strings.Repeat("x", 100*1024)
+	var calls int
+	var lastSent, lastTotal int64
+	err := client.UploadDiskImageWithProgress(server.URL+"/upload", strings.NewReader(image), int64(len(image)), "78805a221a988e79ef3f42d7c5bfd418", "6105d6cc76af400325e94d588ce511be5bfdbb73b437dc51eca43917d7a43e3d", func(bytesSent, totalBytes int64) {
+		if bytesSent < lastSent {
+			t.Errorf("Expected progress to only go up, got %d after %d", bytesSent, lastSent)
+		}
+		calls++
+		lastSent, lastTotal = bytesSent, totalBytes
+	})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if gotBody != image {
+		t.Errorf("Expected %d bytes to be uploaded, got %d", len(image), len(gotBody))
+	}
+	if calls == 0 || lastSent != int64(len(image)) || lastTotal != int64(len(image)) {
+		t.Errorf("Expected progress to finish at %d of %d, got %d of %d after %d calls", len(image), len(image), lastSent, lastTotal, calls)
+	}
+}
+
+func TestUploadDiskImage(t *testing.T) {
+	var gotHeaders http.Header
+	var gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		gotHeaders = req.Header
+		body, _ := io.ReadAll(req.Body)
+		gotBody = string(body)
+		if req.Header.Get("Authorization") != "" {
+			rw.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	err := client.UploadDiskImage(server.URL+"/upload", strings.NewReader("image"), 5, "78805a221a988e79ef3f42d7c5bfd418", "6105d6cc76af400325e94d588ce511be5bfdbb73b437dc51eca43917d7a43e3d")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if gotBody != "image" {
+		t.Errorf("Expected %s, got %s", "image", gotBody)
+	}
+	if gotHeaders.Get("Content-MD5") != "eIBaIhqYjnnvP0LXxb/UGA==" {
+		t.Errorf("Expected %s, got %s", "eIBaIhqYjnnvP0LXxb/UGA==", gotHeaders.Get("Content-MD5"))
+	}
+}
+
+func TestUploadDiskImageUsesHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	defer server.Close()
+
+	transport := &headerRecordingTransport{}
+	client, _ := NewClientWithHTTPClient("TEST-API-KEY", server.URL, "TEST", &http.Client{Transport: transport})
+
+	err := client.UploadDiskImage(server.URL+"/upload", strings.NewReader("image"), 5, "78805a221a988e79ef3f42d7c5bfd418", "6105d6cc76af400325e94d588ce511be5bfdbb73b437dc51eca43917d7a43e3d")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if transport.headers.Get("Content-MD5") != "eIBaIhqYjnnvP0LXxb/UGA==" {
+		t.Errorf("Expected the upload to go through the client's http.Client")
+	}
+}
+
+func TestUploadDiskImageFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		rw.Write([]byte("BadDigest"))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	err := client.UploadDiskImage(server.URL+"/upload", strings.NewReader("image"), 5, "78805a221a988e79ef3f42d7c5bfd418", "6105d6cc76af400325e94d588ce511be5bfdbb73b437dc51eca43917d7a43e3d")
+	if !errors.Is(err, DiskImageUploadFailedError) {
+		t.Errorf("Expected %v, got %v", DiskImageUploadFailedError, err)
+	}
+
+	var httpErr HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusBadRequest || httpErr.Reason != "BadDigest" {
+		t.Errorf("Expected HTTPError with code 400 and reason BadDigest, got %v", err)
+	}
+}
+
+func TestGetDistributionDefault(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/disk_images": `[{"id":"1","name":"ubuntu-focal","distribution":"ubuntu","distribution_default":false},{"id":"2","name":"ubuntu-jammy","distribution":"ubuntu","distribution_default":true},{"id":"3","name":"debian-11","distribution":"debian","distribution_default":false}]`,
+	})
+	defer server.Close()
+
+	got, err := client.GetDistributionDefault("ubuntu")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.ID != "2" {
+		t.Errorf("Expected %s, got %s", "2", got.ID)
+	}
+
+	_, err = client.GetDistributionDefault("debian")
+	if !errors.Is(err, ZeroMatchesError) {
+		t.Errorf("Expected %v, got %v", ZeroMatchesError, err)
+	}
+}
+
+func TestGetMostRecentDistroNonSemver(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/disk_images": `[{"id":"1","name":"ubuntu-focal","version":"20.04"},{"id":"2","name":"ubuntu-jammy","version":"22.04"},{"id":"3","name":"ubuntu-bionic","version":"18.04"}]`,
+	})
+	defer server.Close()
+
+	got, err := client.GetMostRecentDistro("ubuntu")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.Version != "22.04" {
+		t.Errorf("Expected %s, got %s", "22.04", got.Version)
+	}
+}
+
+func TestCompareDistroVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"20.04", "22.04", -1},
+		{"22.04", "18.04", 1},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.10", "1.9", 1},
+		{"11", "11.2", -1},
+		{"jammy", "focal", 1},
+	}
+
+	for _, test := range tests {
+		if got := compareDistroVersions(test.a, test.b); got != test.expected {
+			t.Errorf("compareDistroVersions(%q, %q): expected %d, got %d", test.a, test.b, test.expected, got)
+		}
+	}
+}
+
+func TestListDiskImagesInRegion(t *testing.T) {
+	var requestedURLs []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requestedURLs = append(requestedURLs, req.URL.RequestURI())
+		if req.URL.Path == "/v2/disk_images" {
+			rw.Write([]byte(`[{"id":"1","name":"custom-lon1"}]`))
+			return
+		}
+		rw.Write([]byte(`{"id":"1","name":"custom-lon1"}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.ListDiskImagesInRegion("LON1", true)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(got) != 1 {
+		t.Errorf("Expected %d, got %d", 1, len(got))
+	}
+
+	_, err = client.GetDiskImageInRegion("1", "LON1")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+
+	expected := []string{
+		"/v2/disk_images?region=LON1&type=custom",
+		"/v2/disk_images/1?region=LON1",
+	}
+	if !reflect.DeepEqual(requestedURLs, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, requestedURLs)
+	}
+}
+
+func TestCancelDiskImage(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		methods = append(methods, req.Method+" "+req.URL.RequestURI())
+		if req.Method == "GET" {
+			rw.Write([]byte(`{"id":"12345","state":"processing"}`))
+			return
+		}
+		rw.Write([]byte(`{"result":"success"}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	if err := client.CancelDiskImage("12345"); err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+
+	expected := []string{
+		"GET /v2/disk_images/12345?region=TEST",
+		"DELETE /v2/disk_images/12345?cancel=true&region=TEST",
+	}
+	if !reflect.DeepEqual(methods, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, methods)
+	}
+}
+
+func TestCancelDiskImageTerminalState(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/disk_images/12345": `{"id":"12345","state":"available"}`,
+	})
+	defer server.Close()
+
+	err := client.CancelDiskImage("12345")
+	if !errors.Is(err, DiskImageTerminalStateError) {
+		t.Errorf("Expected %v, got %v", DiskImageTerminalStateError, err)
+	}
+}
+
+func TestDeleteDiskImages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/v2/disk_images/missing" {
+			rw.WriteHeader(http.StatusNotFound)
+			rw.Write([]byte(`{"code":"database_disk_image_not_found","reason":"The requested disk image could not be found"}`))
+			return
+		}
+		rw.Write([]byte(`{"result":"success"}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	ids := []string{"1", "2", "missing", "3", "4", "5", "6"}
+	got, err := client.DeleteDiskImages(ids)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(got) != len(ids) {
+		t.Errorf("Expected %d, got %d", len(ids), len(got))
+		return
+	}
+	for i, result := range got {
+		if result.ID != ids[i] {
+			t.Errorf("Expected %s, got %s", ids[i], result.ID)
+		}
+		if result.ID == "missing" {
+			if result.Deleted || !errors.Is(result.Err, DatabaseDiskImageNotFoundError) {
+				t.Errorf("Expected %v, got %+v", DatabaseDiskImageNotFoundError, result)
+			}
+		} else if !result.Deleted || result.Err != nil {
+			t.Errorf("Expected %s to be deleted, got %+v", result.ID, result)
+		}
+	}
+
+	if _, err := client.DeleteDiskImages([]string{"1", ""}); !errors.Is(err, IDisEmptyError) {
+		t.Errorf("Expected %v, got %v", IDisEmptyError, err)
+	}
+}
+
+func TestDeleteDiskImageAndWait(t *testing.T) {
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "DELETE" {
+			rw.Write([]byte(`{"result":"success"}`))
+			return
+		}
+		gets++
+		if req.URL.Path == "/v2/disk_images/stuck" || gets < 3 {
+			rw.Write([]byte(`{"id": "1", "name": "custom-a", "state": "deleting"}`))
+			return
+		}
+		rw.WriteHeader(http.StatusNotFound)
+		rw.Write([]byte(`{"code":"database_disk_image_not_found","reason":"The requested disk image could not be found"}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	if err := client.DeleteDiskImageAndWait("1", time.Second, time.Millisecond); err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if gets != 3 {
+		t.Errorf("Expected the image to be checked %d times, got %d", 3, gets)
+	}
+
+	err := client.DeleteDiskImageAndWait("stuck", 20*time.Millisecond, time.Millisecond)
+	if !errors.Is(err, TimeoutError) {
+		t.Errorf("Expected %v, got %v", TimeoutError, err)
+	}
+}
+
+func TestDiskImageJSONRoundTrip(t *testing.T) {
+	EnsureJSONRoundTrip(t, DiskImage{
+		ID:                  "b82168fe-66f6-4b38-a3b8-5283542d5475",
+		Name:                "ubuntu-22.04",
+		Version:             "22.04",
+		State:               "available",
+		Distribution:        "ubuntu",
+		Label:               "ubuntu-jammy",
+		CreatedAt:           time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		DistributionDefault: true,
+	})
+
+	got := EnsureJSONRoundTrip(t, DiskImage{})
+	if strings.Contains(got, "distribution_default") {
+		t.Errorf("Expected distribution_default to be omitted, got %s", got)
+	}
+
+	EnsureJSONRoundTrip(t, CreateDiskImageResponse{ID: "12345", Name: "custom", DistributionDefault: true})
+}
+
+func TestDiskImageSize(t *testing.T) {
+	image := DiskImage{DiskImageSizeBytes: 2361393152}
+	if image.SizeGB() != 2.36 {
+		t.Errorf("Expected %v, got %v", 2.36, image.SizeGB())
+	}
+	if image.SizeGiB() != 2.2 {
+		t.Errorf("Expected %v, got %v", 2.2, image.SizeGiB())
+	}
+
+	response := CreateDiskImageResponse{DiskImageSizeBytes: 10 * 1024 * 1024 * 1024}
+	if response.SizeGB() != 10.74 {
+		t.Errorf("Expected %v, got %v", 10.74, response.SizeGB())
+	}
+	if response.SizeGiB() != 10 {
+		t.Errorf("Expected %v, got %v", 10, response.SizeGiB())
+	}
+
+	if (&DiskImage{}).SizeGB() != 0 {
+		t.Errorf("Expected %v, got %v", 0, (&DiskImage{}).SizeGB())
+	}
+}
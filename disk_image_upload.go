@@ -0,0 +1,352 @@
+package civogo
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultUploadChunkSize is the chunk size UploadDiskImageResumable uses if none is given
+const defaultUploadChunkSize = 64 * 1024 * 1024
+
+// ResumableUploadOptions controls UploadDiskImageResumable
+type ResumableUploadOptions struct {
+	// MD5 and SHA256 are the hex encoded checksums that were passed to CreateDiskImage, the
+	// image sent is checked against them once the upload is complete
+	MD5    string
+	SHA256 string
+	// Offset is where to start sending from, e.g. the Offset of an UploadInterruptedError
+	Offset int64
+	// ChunkSize is how many bytes are sent in each request, 64MiB if not set
+	ChunkSize int64
+	// MaxRetries is how many times a failed chunk is retried before giving up
+	MaxRetries int
+	// RetryDelay is how long to wait before retrying a chunk, 1s if not set
+	RetryDelay time.Duration
+	// Progress, if set, is called after each chunk with the bytes uploaded so far
+	Progress UploadProgressFunc
+}
+
+// UploadInterruptedError is returned by UploadDiskImageResumable when it gives up part way
+// through. Offset is how much of the image the upload URL has confirmed it received, pass it as
+// ResumableUploadOptions.Offset to carry on from there
+type UploadInterruptedError struct {
+	Offset int64
+	Err    error
+}
+
+func (e *UploadInterruptedError) Error() string {
+	return fmt.Sprintf("upload interrupted after %d bytes: %v", e.Offset, e.Err)
+}
+
+func (e *UploadInterruptedError) Unwrap() error {
+	return e.Err
+}
+
+// UploadDiskImageResumable uploads the image in r to uploadURL in chunks, so an interrupted
+// upload can carry on from where it stopped rather than starting again. It needs an upload URL
+// that accepts ranged PUTs: each chunk is sent with a Content-Range header, the server answers
+// 308 with a Range header for a partial upload and 2xx once the whole image is received, and
+// "Content-Range: bytes */size" asks how much it has. Plain single-PUT URLs should use
+// UploadDiskImage instead.
+//
+// The image is hashed as it's sent. Once the upload is complete the hashes are checked against
+// opts.MD5 and opts.SHA256, and against any checksum the server reports for what it received
+// (an MD5 in X-Goog-Hash, Content-MD5 or a plain ETag, or a SHA256 in X-Amz-Checksum-Sha256),
+// returning a DiskImageChecksumError if any of them differ
+func (c *Client) UploadDiskImageResumable(uploadURL string, r io.ReaderAt, size int64, opts ResumableUploadOptions) error {
+	return c.UploadDiskImageResumableWithContext(context.Background(), uploadURL, r, size, opts)
+}
+
+// UploadDiskImageResumableWithContext is UploadDiskImageResumable bound to ctx
+func (c *Client) UploadDiskImageResumableWithContext(ctx context.Context, uploadURL string, r io.ReaderAt, size int64, opts ResumableUploadOptions) error {
+	if !isHexOfLength(opts.MD5, 32) {
+		return ParameterChecksumInvalidError.wrap(fmt.Errorf("md5sum must be 32 hex characters, got %q", opts.MD5))
+	}
+	if !isHexOfLength(opts.SHA256, 64) {
+		return ParameterChecksumInvalidError.wrap(fmt.Errorf("sha256sum must be 64 hex characters, got %q", opts.SHA256))
+	}
+
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultUploadChunkSize
+	}
+	retryDelay := opts.RetryDelay
+	if retryDelay <= 0 {
+		retryDelay = time.Second
+	}
+
+	checksums := newUploadChecksums()
+	offset := opts.Offset
+	retries := 0
+	for offset < size {
+		end := offset + chunkSize
+		if end > size {
+			end = size
+		}
+
+		// the chunk is hashed as it's sent if the hashes have reached its start, and the hashes
+		// are put back if the server doesn't keep all of it
+		chunk := &hashingReader{r: io.NewSectionReader(r, offset, end-offset)}
+		var saved [2][]byte
+		teed := checksums.hashed == offset
+		if teed {
+			saved = checksums.save()
+			chunk.w = checksums
+		}
+
+		chunkCtx, cancel := c.withUploadTimeout(ctx)
+		result, err := c.putUploadChunk(chunkCtx, uploadURL, chunk, offset, end, size)
+		cancel()
+		chunk.stop()
+		if err == nil && !result.done && result.received <= offset {
+			err = DiskImageUploadFailedError.wrap(fmt.Errorf("the upload URL didn't keep any of bytes %d-%d", offset, end-1))
+		}
+		if teed && (err != nil || result.received != end || checksums.hashed != end) {
+			checksums.restore(saved)
+			checksums.hashed = offset
+		}
+
+		if err == nil {
+			offset, retries = result.received, 0
+			if opts.Progress != nil {
+				opts.Progress(offset, size)
+			}
+			if result.done {
+				return checksums.verify(r, size, opts, result.header)
+			}
+			continue
+		}
+
+		if retries >= opts.MaxRetries || ctx.Err() != nil {
+			return &UploadInterruptedError{Offset: offset, Err: err}
+		}
+		retries++
+
+		select {
+		case <-ctx.Done():
+			return &UploadInterruptedError{Offset: offset, Err: ctx.Err()}
+		case <-time.After(retryDelay):
+		}
+
+		// the chunk may have partly arrived, so ask where to carry on from
+		if result, err := c.queryUploadOffset(ctx, uploadURL, size); err == nil {
+			if result.done {
+				return checksums.verify(r, size, opts, result.header)
+			}
+			offset = result.received
+		}
+	}
+
+	// a zero length image, or one resumed at its end, still needs the server to confirm it
+	result, err := c.queryUploadOffset(ctx, uploadURL, size)
+	if err != nil {
+		return &UploadInterruptedError{Offset: offset, Err: err}
+	}
+	if !result.done {
+		return &UploadInterruptedError{Offset: offset, Err: DiskImageUploadFailedError.wrap(fmt.Errorf("the upload URL didn't confirm the upload is complete"))}
+	}
+	return checksums.verify(r, size, opts, result.header)
+}
+
+// uploadRangeResult is how much of the image the upload URL says it has
+type uploadRangeResult struct {
+	received int64
+	// done is set once the whole image has been received, header is then the final response's
+	done   bool
+	header http.Header
+}
+
+// putUploadChunk sends bytes start to end (exclusive) of the image
+func (c *Client) putUploadChunk(ctx context.Context, uploadURL string, chunk io.Reader, start, end, size int64) (uploadRangeResult, error) {
+	req, err := http.NewRequestWithContext(ctx, "PUT", uploadURL, chunk)
+	if err != nil {
+		return uploadRangeResult{received: start}, err
+	}
+	req.ContentLength = end - start
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end-1, size))
+
+	return c.doUploadRangeRequest(req, end)
+}
+
+// queryUploadOffset asks the upload URL how much of the image it has received
+func (c *Client) queryUploadOffset(ctx context.Context, uploadURL string, size int64) (uploadRangeResult, error) {
+	req, err := http.NewRequestWithContext(ctx, "PUT", uploadURL, nil)
+	if err != nil {
+		return uploadRangeResult{}, err
+	}
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+
+	return c.doUploadRangeRequest(req, size)
+}
+
+// doUploadRangeRequest sends req and reads how much of the image the server has from the
+// response: all of it (done, at offset end) for a 2xx, or the Range header of a 308. The upload
+// URL is pre-signed, so the request goes through the client's http.Client without the API
+// credentials
+func (c *Client) doUploadRangeRequest(req *http.Request, end int64) (uploadRangeResult, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return uploadRangeResult{}, decodeError(err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return uploadRangeResult{received: end, done: true, header: resp.Header}, nil
+	case resp.StatusCode == http.StatusPermanentRedirect:
+		// no Range header means nothing has been received yet
+		received, _ := parseUploadRange(resp.Header.Get("Range"))
+		return uploadRangeResult{received: received}, nil
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return uploadRangeResult{}, DiskImageUploadFailedError.wrap(HTTPError{Code: resp.StatusCode, Status: resp.Status, Reason: string(body)})
+	}
+}
+
+// parseUploadRange reads a "bytes=0-N" Range header, returning how many bytes it covers
+func parseUploadRange(value string) (int64, bool) {
+	_, last, ok := strings.Cut(strings.TrimPrefix(value, "bytes="), "-")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(last, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n + 1, true
+}
+
+// hashingReader copies what's read from r to w, if set, until stop is called. The transport may
+// still be reading a request body after Do returns, so stop makes sure nothing more is hashed
+type hashingReader struct {
+	mu      sync.Mutex
+	r       io.Reader
+	w       io.Writer
+	stopped bool
+}
+
+func (h *hashingReader) Read(p []byte) (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.stopped {
+		return 0, io.ErrUnexpectedEOF
+	}
+	n, err := h.r.Read(p)
+	if n > 0 && h.w != nil {
+		h.w.Write(p[:n])
+	}
+	return n, err
+}
+
+func (h *hashingReader) stop() {
+	h.mu.Lock()
+	h.stopped = true
+	h.mu.Unlock()
+}
+
+// uploadChecksums hashes an image in order as it's uploaded. hashed is how many bytes, from the
+// start of the image, have gone into the hashes
+type uploadChecksums struct {
+	md5    hash.Hash
+	sha256 hash.Hash
+	hashed int64
+}
+
+func newUploadChecksums() *uploadChecksums {
+	return &uploadChecksums{md5: md5.New(), sha256: sha256.New()}
+}
+
+func (u *uploadChecksums) Write(p []byte) (int, error) {
+	u.md5.Write(p)
+	u.sha256.Write(p)
+	u.hashed += int64(len(p))
+	return len(p), nil
+}
+
+// save returns the state of the hashes, for restore to go back to
+func (u *uploadChecksums) save() [2][]byte {
+	md5State, _ := u.md5.(encoding.BinaryMarshaler).MarshalBinary()
+	sha256State, _ := u.sha256.(encoding.BinaryMarshaler).MarshalBinary()
+	return [2][]byte{md5State, sha256State}
+}
+
+func (u *uploadChecksums) restore(state [2][]byte) {
+	u.md5.(encoding.BinaryUnmarshaler).UnmarshalBinary(state[0])
+	u.sha256.(encoding.BinaryUnmarshaler).UnmarshalBinary(state[1])
+}
+
+// verify hashes whatever of the image wasn't hashed while it was sent (the part uploaded before a
+// resume, or a chunk the server only kept some of), then checks the image's checksums against
+// the ones it was created with and any the server reports in header
+func (u *uploadChecksums) verify(r io.ReaderAt, size int64, opts ResumableUploadOptions, header http.Header) error {
+	if u.hashed > size {
+		*u = *newUploadChecksums()
+	}
+	if _, err := io.Copy(u, io.NewSectionReader(r, u.hashed, size-u.hashed)); err != nil {
+		return err
+	}
+
+	md5sum := hex.EncodeToString(u.md5.Sum(nil))
+	sha256sum := hex.EncodeToString(u.sha256.Sum(nil))
+	if !strings.EqualFold(md5sum, opts.MD5) {
+		return DiskImageChecksumError.wrap(fmt.Errorf("the uploaded image's md5 is %s, expected %s", md5sum, opts.MD5))
+	}
+	if !strings.EqualFold(sha256sum, opts.SHA256) {
+		return DiskImageChecksumError.wrap(fmt.Errorf("the uploaded image's sha256 is %s, expected %s", sha256sum, opts.SHA256))
+	}
+
+	serverMD5, serverSHA256 := uploadedChecksums(header)
+	if serverMD5 != "" && !strings.EqualFold(serverMD5, md5sum) {
+		return DiskImageChecksumError.wrap(fmt.Errorf("the upload URL received an image with md5 %s, expected %s", serverMD5, md5sum))
+	}
+	if serverSHA256 != "" && !strings.EqualFold(serverSHA256, sha256sum) {
+		return DiskImageChecksumError.wrap(fmt.Errorf("the upload URL received an image with sha256 %s, expected %s", serverSHA256, sha256sum))
+	}
+	return nil
+}
+
+// uploadedChecksums reads the hex encoded md5 and sha256 of the uploaded image from the headers
+// of the response completing the upload, each empty if the server didn't give it
+func uploadedChecksums(header http.Header) (string, string) {
+	md5sum, sha256sum := "", ""
+	if header == nil {
+		return md5sum, sha256sum
+	}
+
+	for _, part := range strings.Split(header.Get("X-Goog-Hash"), ",") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(part), "md5="); ok {
+			md5sum = base64ToHex(value)
+		}
+	}
+	if md5sum == "" {
+		md5sum = base64ToHex(header.Get("Content-MD5"))
+	}
+	// a multipart upload's ETag isn't an md5 of the whole image, so only a plain one is used
+	if etag := strings.Trim(header.Get("ETag"), `"`); md5sum == "" && isHexOfLength(etag, 32) {
+		md5sum = etag
+	}
+
+	sha256sum = base64ToHex(header.Get("X-Amz-Checksum-Sha256"))
+	return md5sum, sha256sum
+}
+
+func base64ToHex(value string) string {
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || len(decoded) == 0 {
+		return ""
+	}
+	return hex.EncodeToString(decoded)
+}
@@ -0,0 +1,191 @@
+package civogo
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// resumableUploadServer accepts ranged PUTs, keeping what it's sent. failChunks is how many
+// chunk requests to fail (after keeping half of the chunk) before accepting them
+type resumableUploadServer struct {
+	received   []byte
+	size       int
+	failChunks int
+	// header is added to the response completing the upload
+	header http.Header
+}
+
+func (s *resumableUploadServer) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	contentRange := strings.TrimPrefix(req.Header.Get("Content-Range"), "bytes ")
+	if !strings.HasPrefix(contentRange, "*/") {
+		body, _ := io.ReadAll(req.Body)
+		start, _ := strconv.Atoi(strings.Split(contentRange, "-")[0])
+		if start != len(s.received) {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if s.failChunks > 0 {
+			s.failChunks--
+			s.received = append(s.received, body[:len(body)/2]...)
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		s.received = append(s.received, body...)
+	}
+
+	if len(s.received) == s.size {
+		for name, values := range s.header {
+			rw.Header()[name] = values
+		}
+		rw.WriteHeader(http.StatusCreated)
+		return
+	}
+	if len(s.received) > 0 {
+		rw.Header().Set("Range", fmt.Sprintf("bytes=0-%d", len(s.received)-1))
+	}
+	rw.WriteHeader(http.StatusPermanentRedirect)
+}
+
+func imageChecksums(image string) (string, string) {
+	md5Sum := md5.Sum([]byte(image))
+	sha256Sum := sha256.Sum256([]byte(image))
+	return hex.EncodeToString(md5Sum[:]), hex.EncodeToString(sha256Sum[:])
+}
+
+func TestUploadDiskImageResumable(t *testing.T) {
+	image := strings.Repeat("0123456789", 100)
+	md5sum, sha256sum := imageChecksums(image)
+
+	backend := &resumableUploadServer{size: len(image), failChunks: 1}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	var progress []int64
+	err := client.UploadDiskImageResumable(server.URL+"/upload", strings.NewReader(image), int64(len(image)), ResumableUploadOptions{
+		MD5:        md5sum,
+		SHA256:     sha256sum,
+		ChunkSize:  300,
+		MaxRetries: 1,
+		RetryDelay: 1,
+		Progress:   func(bytesSent, totalBytes int64) { progress = append(progress, bytesSent) },
+	})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if string(backend.received) != image {
+		t.Errorf("Expected the whole image to be received, got %d bytes", len(backend.received))
+	}
+	// the first chunk half arrived before failing, so the upload carries on from byte 150
+	expected := "[450 750 1000]"
+	if fmt.Sprint(progress) != expected {
+		t.Errorf("Expected %s, got %v", expected, progress)
+	}
+}
+
+func TestUploadDiskImageResumableInterrupted(t *testing.T) {
+	image := strings.Repeat("0123456789", 100)
+	md5sum, sha256sum := imageChecksums(image)
+
+	backend := &resumableUploadServer{size: len(image), failChunks: 1}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	opts := ResumableUploadOptions{MD5: md5sum, SHA256: sha256sum, ChunkSize: 400}
+	err := client.UploadDiskImageResumable(server.URL+"/upload", strings.NewReader(image), int64(len(image)), opts)
+	var interrupted *UploadInterruptedError
+	if !errors.As(err, &interrupted) {
+		t.Errorf("Expected an UploadInterruptedError, got %v", err)
+		return
+	}
+	if !errors.Is(err, DiskImageUploadFailedError) {
+		t.Errorf("Expected %v, got %v", DiskImageUploadFailedError, err)
+	}
+
+	if interrupted.Offset != 0 {
+		t.Errorf("Expected the upload to be interrupted at 0, got %d", interrupted.Offset)
+	}
+
+	// the server kept half of the failed chunk, so carry on from there
+	opts.Offset = int64(len(backend.received))
+	if err := client.UploadDiskImageResumable(server.URL+"/upload", strings.NewReader(image), int64(len(image)), opts); err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if string(backend.received) != image {
+		t.Errorf("Expected the whole image to be received, got %d bytes", len(backend.received))
+	}
+}
+
+func TestUploadDiskImageResumableChecksum(t *testing.T) {
+	image := strings.Repeat("0123456789", 100)
+	md5sum, sha256sum := imageChecksums(image)
+	otherMD5, otherSHA256 := imageChecksums("another image")
+	md5Bytes, _ := hex.DecodeString(md5sum)
+	otherMD5Bytes, _ := hex.DecodeString(otherMD5)
+
+	tests := []struct {
+		name     string
+		md5sum   string
+		sha256   string
+		header   http.Header
+		expected error
+	}{
+		{"invalid checksum", "not-hex", sha256sum, nil, ParameterChecksumInvalidError},
+		{"image doesn't match", otherMD5, otherSHA256, nil, DiskImageChecksumError},
+		{"server md5 matches", md5sum, sha256sum, http.Header{"X-Goog-Hash": {"crc32c=AAAAAA==,md5=" + base64.StdEncoding.EncodeToString(md5Bytes)}}, nil},
+		{"server md5 differs", md5sum, sha256sum, http.Header{"X-Goog-Hash": {"crc32c=AAAAAA==,md5=" + base64.StdEncoding.EncodeToString(otherMD5Bytes)}}, DiskImageChecksumError},
+		{"server etag differs", md5sum, sha256sum, http.Header{"Etag": {`"` + otherMD5 + `"`}}, DiskImageChecksumError},
+		{"multipart etag ignored", md5sum, sha256sum, http.Header{"Etag": {`"` + otherMD5 + `-3"`}}, nil},
+	}
+	for _, test := range tests {
+		backend := &resumableUploadServer{size: len(image), failChunks: 1, header: test.header}
+		server := httptest.NewServer(backend)
+		client, _ := NewClientForTestingWithServer(server)
+
+		err := client.UploadDiskImageResumable(server.URL+"/upload", strings.NewReader(image), int64(len(image)), ResumableUploadOptions{MD5: test.md5sum, SHA256: test.sha256, ChunkSize: 300, MaxRetries: 1, RetryDelay: 1})
+		server.Close()
+		if test.expected == nil && err != nil {
+			t.Errorf("%s: expected no error, got %v", test.name, err)
+		}
+		if test.expected != nil && !errors.Is(err, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, err)
+		}
+	}
+}
+
+func TestUploadDiskImageResumableUsesHTTPClient(t *testing.T) {
+	image := strings.Repeat("0123456789", 100)
+	md5sum, sha256sum := imageChecksums(image)
+
+	backend := &resumableUploadServer{size: len(image)}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	transport := &headerRecordingTransport{}
+	client, _ := NewClientWithHTTPClient("TEST-API-KEY", server.URL, "TEST", &http.Client{Transport: transport})
+
+	err := client.UploadDiskImageResumable(server.URL+"/upload", strings.NewReader(image), int64(len(image)), ResumableUploadOptions{MD5: md5sum, SHA256: sha256sum})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if transport.headers.Get("Content-Range") == "" {
+		t.Errorf("Expected the upload to go through the client's http.Client")
+	}
+	if transport.headers.Get("Authorization") != "" {
+		t.Errorf("Expected the upload not to carry the API credentials, got %s", transport.headers.Get("Authorization"))
+	}
+}
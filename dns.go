@@ -24,7 +24,7 @@ type dnsDomainConfig struct {
 	Name string `json:"name"`
 }
 
-// DNSRecordType represents the allowed record types: a, cname, mx or txt
+// DNSRecordType represents the allowed record types: a, aaaa, cname, mx, srv, txt or ns
 type DNSRecordType string
 
 // DNSRecord represents a DNS record registered within Civo's infrastructure
@@ -56,6 +56,9 @@ const (
 	// DNSRecordTypeA represents an A record
 	DNSRecordTypeA = "A"
 
+	// DNSRecordTypeAAAA represents an AAAA record
+	DNSRecordTypeAAAA = "AAAA"
+
 	// DNSRecordTypeCName represents an CNAME record
 	DNSRecordTypeCName = "CNAME"
 
@@ -72,6 +75,10 @@ const (
 	DNSRecordTypeNS = "NS"
 )
 
+// DefaultDNSMinimumTTL is the lowest TTL, in seconds, Civo keeps for a DNS record; lower values
+// are raised to it by the API
+const DefaultDNSMinimumTTL = 600
+
 var (
 	// ErrDNSDomainNotFound is returned when the domain is not found
 	ErrDNSDomainNotFound = fmt.Errorf("domain not found")
@@ -193,11 +200,32 @@ func (c *Client) DeleteDNSDomain(d *DNSDomain) (*SimpleResponse, error) {
 	return c.DecodeSimpleResponse(resp)
 }
 
-// CreateDNSRecord creates a new DNS record
+// SetDNSMinimumTTL sets the lowest TTL CreateDNSRecord and UpdateDNSRecord accept, which is
+// DefaultDNSMinimumTTL for a new client. A record TTL of 0 is always accepted, as it means the
+// API's default; a minimum of 0 or less turns the check off
+func (c *Client) SetDNSMinimumTTL(ttl int) {
+	c.dnsMinimumTTL = ttl
+}
+
+// checkDNSRecordTTL rejects a TTL below the client's minimum, as the API would silently raise it
+// and the record would never match its config
+func (c *Client) checkDNSRecordTTL(r *DNSRecordConfig) error {
+	if c.dnsMinimumTTL <= 0 || r.TTL == 0 || r.TTL >= c.dnsMinimumTTL {
+		return nil
+	}
+	err := fmt.Errorf("TTL %d is below the minimum of %d", r.TTL, c.dnsMinimumTTL)
+	return ParameterDNSRecordTTLError.wrap(err)
+}
+
+// CreateDNSRecord creates a new DNS record. A TTL below the minimum (see SetDNSMinimumTTL) returns
+// a ParameterDNSRecordTTLError rather than being raised by the API
 func (c *Client) CreateDNSRecord(domainID string, r *DNSRecordConfig) (*DNSRecord, error) {
 	if len(domainID) == 0 {
 		return nil, fmt.Errorf("r.DomainID is empty")
 	}
+	if err := c.checkDNSRecordTTL(r); err != nil {
+		return nil, err
+	}
 
 	url := fmt.Sprintf("/v2/dns/%s/records", domainID)
 	body, err := c.SendPostRequest(url, r)
@@ -246,8 +274,13 @@ func (c *Client) GetDNSRecord(domainID, domainRecordID string) (*DNSRecord, erro
 	return nil, ErrDNSRecordNotFound
 }
 
-// UpdateDNSRecord updates the DNS record
+// UpdateDNSRecord updates the DNS record. As with CreateDNSRecord, a TTL below the minimum returns
+// a ParameterDNSRecordTTLError
 func (c *Client) UpdateDNSRecord(r *DNSRecord, rc *DNSRecordConfig) (*DNSRecord, error) {
+	if err := c.checkDNSRecordTTL(rc); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("/v2/dns/%s/records/%s", r.DNSDomainID, r.ID)
 	body, err := c.SendPutRequest(url, rc)
 	if err != nil {
@@ -262,6 +295,43 @@ func (c *Client) UpdateDNSRecord(r *DNSRecord, rc *DNSRecordConfig) (*DNSRecord,
 	return dnsRecord, nil
 }
 
+// UpsertDNSRecord creates the DNS record within the domain, unless a record with the same name
+// (case-insensitive) and type already exists, in which case that record is updated instead. The
+// second return value is true when a new record was created and false when one was updated
+func (c *Client) UpsertDNSRecord(domainID string, record DNSRecordConfig) (*DNSRecord, bool, error) {
+	if len(domainID) == 0 {
+		err := fmt.Errorf("the domain ID is empty")
+		return nil, false, IDisEmptyError.wrap(err)
+	}
+
+	records, err := c.ListDNSRecords(domainID)
+	if err != nil {
+		return nil, false, decodeError(err)
+	}
+
+	for i := range records {
+		existing := &records[i]
+		if !strings.EqualFold(existing.Name, record.Name) || !strings.EqualFold(string(existing.Type), string(record.Type)) {
+			continue
+		}
+
+		if existing.DNSDomainID == "" {
+			existing.DNSDomainID = domainID
+		}
+		updated, err := c.UpdateDNSRecord(existing, &record)
+		if err != nil {
+			return nil, false, err
+		}
+		return updated, false, nil
+	}
+
+	created, err := c.CreateDNSRecord(domainID, &record)
+	if err != nil {
+		return nil, false, err
+	}
+	return created, true, nil
+}
+
 // DeleteDNSRecord deletes the DNS record
 func (c *Client) DeleteDNSRecord(r *DNSRecord) (*SimpleResponse, error) {
 	if len(r.ID) == 0 {
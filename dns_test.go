@@ -1,6 +1,9 @@
 package civogo
 
 import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 	"time"
@@ -310,3 +313,64 @@ func TestGetRecord(t *testing.T) {
 		return
 	}
 }
+
+func TestUpsertDNSRecord(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "GET":
+			rw.Write([]byte(`[{"id": "12345", "domain_id": "1111", "name": "WWW", "type": "A", "value": "10.0.0.0", "ttl": 600}]`))
+		case req.Method == "PUT" && req.URL.Path == "/v2/dns/1111/records/12345":
+			rw.Write([]byte(`{"id": "12345", "domain_id": "1111", "name": "www", "type": "A", "value": "10.0.0.9", "ttl": 900}`))
+		case req.Method == "POST" && req.URL.Path == "/v2/dns/1111/records":
+			rw.Write([]byte(`{"id": "12346", "domain_id": "1111", "name": "mail", "type": "MX", "value": "10.0.0.1", "ttl": 600, "priority": 10}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, created, err := client.UpsertDNSRecord("1111", DNSRecordConfig{Name: "www", Type: DNSRecordTypeA, Value: "10.0.0.9", TTL: 900})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if created || got.ID != "12345" || got.Value != "10.0.0.9" || got.TTL != 900 {
+		t.Errorf("Expected record 12345 to be updated, got created=%t %+v", created, got)
+	}
+
+	got, created, err = client.UpsertDNSRecord("1111", DNSRecordConfig{Name: "mail", Type: DNSRecordTypeMX, Value: "10.0.0.1", Priority: 10, TTL: 600})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if !created || got.ID != "12346" {
+		t.Errorf("Expected record 12346 to be created, got created=%t %+v", created, got)
+	}
+}
+
+func TestDNSRecordMinimumTTL(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/dns/12345/records": `{"id": "76cc107f-fbef-4e2b-b97f-f5d34f4075d3", "domain_id": "12345", "name": "mail", "type": "MX", "value": "10.0.0.1", "ttl": 300}`,
+	})
+	defer server.Close()
+
+	cfg := &DNSRecordConfig{Type: DNSRecordTypeMX, Name: "mail", Value: "10.0.0.1", Priority: 10, TTL: 300}
+	if _, err := client.CreateDNSRecord("12345", cfg); !errors.Is(err, ParameterDNSRecordTTLError) {
+		t.Errorf("Expected %v, got %v", ParameterDNSRecordTTLError, err)
+	}
+	record := &DNSRecord{ID: "76cc107f-fbef-4e2b-b97f-f5d34f4075d3", DNSDomainID: "12345"}
+	if _, err := client.UpdateDNSRecord(record, cfg); !errors.Is(err, ParameterDNSRecordTTLError) {
+		t.Errorf("Expected %v, got %v", ParameterDNSRecordTTLError, err)
+	}
+
+	client.SetDNSMinimumTTL(60)
+	got, err := client.CreateDNSRecord("12345", cfg)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.TTL != 300 {
+		t.Errorf("Expected %d, got %d", 300, got.TTL)
+	}
+}
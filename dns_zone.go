@@ -0,0 +1,413 @@
+package civogo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ZoneImportResult summarises an ImportDNSZone run. Errors holds one entry for each failed
+// record, naming the zone file line it came from
+type ZoneImportResult struct {
+	Created int     `json:"created"`
+	Skipped int     `json:"skipped"`
+	Failed  int     `json:"failed"`
+	Errors  []error `json:"-"`
+}
+
+// zoneRecord is a single resource record read from a zone file
+type zoneRecord struct {
+	line   int
+	config DNSRecordConfig
+}
+
+// zoneImportTypes are the record types ImportDNSZone creates, anything else (SOA, NS...) is skipped
+var zoneImportTypes = map[string]bool{
+	DNSRecordTypeA:     true,
+	DNSRecordTypeAAAA:  true,
+	DNSRecordTypeCName: true,
+	DNSRecordTypeMX:    true,
+	DNSRecordTypeTXT:   true,
+	DNSRecordTypeSRV:   true,
+}
+
+// ImportDNSZone reads a BIND zone file and creates its A, AAAA, CNAME, MX, TXT and SRV records
+// within the domain. Records the domain already has with the same name, type, value and priority
+// are skipped, as are records of any other type. A TTL below the client's minimum DNS TTL (see
+// SetDNSMinimumTTL) is raised to it. A record that can't be parsed or created is counted as
+// failed and the import carries on with the rest of the file
+func (c *Client) ImportDNSZone(domainID string, zoneFile io.Reader) (*ZoneImportResult, error) {
+	if len(domainID) == 0 {
+		err := fmt.Errorf("the domain ID is empty")
+		return nil, IDisEmptyError.wrap(err)
+	}
+
+	domains, err := c.ListDNSDomains()
+	if err != nil {
+		return nil, decodeError(err)
+	}
+
+	domainName := ""
+	for _, d := range domains {
+		if d.ID == domainID {
+			domainName = d.Name
+			break
+		}
+	}
+	if domainName == "" {
+		return nil, ErrDNSDomainNotFound
+	}
+
+	result := &ZoneImportResult{}
+	records, parseErrors, err := parseZoneFile(zoneFile, domainName)
+	if err != nil {
+		return nil, err
+	}
+	result.Failed += len(parseErrors)
+	result.Errors = append(result.Errors, parseErrors...)
+
+	existing, err := c.ListDNSRecords(domainID)
+	if err != nil {
+		return nil, decodeError(err)
+	}
+
+	for _, r := range records {
+		if !zoneImportTypes[string(r.config.Type)] {
+			result.Skipped++
+			continue
+		}
+
+		if zoneRecordExists(existing, r.config) {
+			result.Skipped++
+			continue
+		}
+
+		// zone files often use TTLs below Civo's minimum, which the API would raise anyway
+		config := r.config
+		if config.TTL > 0 && config.TTL < c.dnsMinimumTTL {
+			config.TTL = c.dnsMinimumTTL
+		}
+		record, err := c.CreateDNSRecord(domainID, &config)
+		if err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Errorf("line %d: %w", r.line, err))
+			continue
+		}
+
+		result.Created++
+		existing = append(existing, *record)
+	}
+
+	return result, nil
+}
+
+// zoneRecordExists reports whether records already has one matching config
+func zoneRecordExists(records []DNSRecord, config DNSRecordConfig) bool {
+	for _, r := range records {
+		if strings.EqualFold(r.Name, config.Name) &&
+			strings.EqualFold(string(r.Type), string(config.Type)) &&
+			r.Value == config.Value &&
+			r.Priority == config.Priority {
+			return true
+		}
+	}
+	return false
+}
+
+// parseZoneFile reads the resource records from a BIND zone file for the named domain. Lines
+// that can't be understood are returned as errors rather than stopping the parse; only a failure
+// to read zoneFile is returned as err
+func parseZoneFile(zoneFile io.Reader, domain string) ([]zoneRecord, []error, error) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	origin := domain
+	defaultTTL := 0
+	owner := "@"
+
+	var records []zoneRecord
+	var parseErrors []error
+
+	scanner := bufio.NewScanner(zoneFile)
+	lineNumber := 0
+	for {
+		entry, startLine, ok := nextZoneEntry(scanner, &lineNumber)
+		if !ok {
+			break
+		}
+
+		tokens := zoneTokens(entry)
+		if len(tokens) == 0 {
+			continue
+		}
+
+		fail := func(format string, args ...interface{}) {
+			parseErrors = append(parseErrors, fmt.Errorf("line %d: "+format, append([]interface{}{startLine}, args...)...))
+		}
+
+		if strings.HasPrefix(tokens[0], "$") {
+			switch strings.ToUpper(tokens[0]) {
+			case "$ORIGIN":
+				if len(tokens) < 2 {
+					fail("$ORIGIN needs a name")
+					continue
+				}
+				origin = zoneAbsoluteName(tokens[1], origin)
+			case "$TTL":
+				ttl, ok := zoneTTL(tokens[1:])
+				if !ok {
+					fail("$TTL needs a time to live")
+					continue
+				}
+				defaultTTL = ttl
+			default:
+				fail("unsupported directive %s", tokens[0])
+			}
+			continue
+		}
+
+		// an entry starting with whitespace belongs to the previous owner
+		if entry[0] != ' ' && entry[0] != '\t' {
+			owner = zoneAbsoluteName(tokens[0], origin)
+			tokens = tokens[1:]
+		}
+
+		ttl := defaultTTL
+		for len(tokens) > 0 {
+			if seconds, ok := zoneTTL(tokens[:1]); ok {
+				ttl = seconds
+			} else if !zoneClasses[strings.ToUpper(tokens[0])] {
+				break
+			}
+			tokens = tokens[1:]
+		}
+		if len(tokens) == 0 {
+			fail("missing record type")
+			continue
+		}
+
+		recordType := strings.ToUpper(tokens[0])
+		data := tokens[1:]
+
+		name, ok := zoneRelativeName(owner, domain)
+		if !ok {
+			fail("%s is outside of %s", owner, domain)
+			continue
+		}
+
+		config := DNSRecordConfig{Type: DNSRecordType(recordType), Name: name, TTL: ttl}
+		switch recordType {
+		case DNSRecordTypeA, DNSRecordTypeAAAA:
+			if len(data) != 1 {
+				fail("%s record needs an address", recordType)
+				continue
+			}
+			config.Value = data[0]
+		case DNSRecordTypeCName:
+			if len(data) != 1 {
+				fail("CNAME record needs a target")
+				continue
+			}
+			config.Value = zoneAbsoluteName(data[0], origin)
+		case DNSRecordTypeMX:
+			if len(data) != 2 {
+				fail("MX record needs a preference and an exchange")
+				continue
+			}
+			priority, err := strconv.Atoi(data[0])
+			if err != nil {
+				fail("invalid MX preference %s", data[0])
+				continue
+			}
+			config.Priority = priority
+			config.Value = zoneAbsoluteName(data[1], origin)
+		case DNSRecordTypeSRV:
+			if len(data) != 4 {
+				fail("SRV record needs a priority, weight, port and target")
+				continue
+			}
+			priority, err := strconv.Atoi(data[0])
+			if err != nil {
+				fail("invalid SRV priority %s", data[0])
+				continue
+			}
+			config.Priority = priority
+			config.Value = strings.Join([]string{data[1], data[2], zoneAbsoluteName(data[3], origin)}, " ")
+		case DNSRecordTypeTXT:
+			if len(data) == 0 {
+				fail("TXT record needs a value")
+				continue
+			}
+			var value strings.Builder
+			for _, d := range data {
+				value.WriteString(zoneUnquote(d))
+			}
+			config.Value = value.String()
+		default:
+			config.Value = strings.Join(data, " ")
+		}
+
+		records = append(records, zoneRecord{line: startLine, config: config})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return records, parseErrors, nil
+}
+
+// zoneClasses are the record classes that may appear before the record type
+var zoneClasses = map[string]bool{"IN": true, "CH": true, "HS": true, "CS": true}
+
+// nextZoneEntry returns the next entry in the zone file with comments removed, joining lines
+// wrapped in parentheses into one. startLine is the line number the entry began on
+func nextZoneEntry(scanner *bufio.Scanner, lineNumber *int) (entry string, startLine int, ok bool) {
+	var b strings.Builder
+	depth := 0
+
+	for scanner.Scan() {
+		*lineNumber++
+		if b.Len() == 0 {
+			startLine = *lineNumber
+		}
+
+		inQuote, escaped := false, false
+	chars:
+		for _, r := range scanner.Text() {
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == '"':
+				inQuote = !inQuote
+			case inQuote:
+			case r == ';':
+				break chars
+			case r == '(':
+				depth++
+				r = ' '
+			case r == ')':
+				depth--
+				r = ' '
+			}
+			b.WriteRune(r)
+		}
+
+		if depth > 0 {
+			b.WriteRune(' ')
+			continue
+		}
+		if strings.TrimSpace(b.String()) == "" {
+			b.Reset()
+			continue
+		}
+		return b.String(), startLine, true
+	}
+
+	if strings.TrimSpace(b.String()) != "" {
+		return b.String(), startLine, true
+	}
+	return "", 0, false
+}
+
+// zoneTokens splits an entry on whitespace, keeping quoted strings (with their quotes) whole
+func zoneTokens(entry string) []string {
+	var tokens []string
+	var current strings.Builder
+	inQuote, escaped := false, false
+
+	for _, r := range entry {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			inQuote = !inQuote
+		case !inQuote && (r == ' ' || r == '\t'):
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens
+}
+
+// zoneUnquote strips the quotes and escapes from a character string
+func zoneUnquote(s string) string {
+	s = strings.TrimSuffix(strings.TrimPrefix(s, `"`), `"`)
+
+	var b strings.Builder
+	escaped := false
+	for _, r := range s {
+		if !escaped && r == '\\' {
+			escaped = true
+			continue
+		}
+		escaped = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// zoneTTL parses a time to live, either in seconds or with BIND's unit suffixes (1h30m, 1d...)
+func zoneTTL(tokens []string) (int, bool) {
+	if len(tokens) == 0 || tokens[0] == "" {
+		return 0, false
+	}
+
+	units := map[byte]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	s := strings.ToLower(tokens[0])
+	total, number := 0, ""
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] >= '0' && s[i] <= '9':
+			number += string(s[i])
+		case units[s[i]] > 0 && number != "":
+			n, _ := strconv.Atoi(number)
+			total += n * units[s[i]]
+			number = ""
+		default:
+			return 0, false
+		}
+	}
+	if number != "" {
+		n, _ := strconv.Atoi(number)
+		total += n
+	}
+
+	return total, true
+}
+
+// zoneAbsoluteName resolves name against origin, returning it without the trailing dot
+func zoneAbsoluteName(name, origin string) string {
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return strings.ToLower(strings.TrimSuffix(name, "."))
+	default:
+		return strings.ToLower(name) + "." + origin
+	}
+}
+
+// zoneRelativeName turns an absolute name into the record name Civo expects for domain, "@"
+// being the domain itself. ok is false if the name isn't within domain
+func zoneRelativeName(name, domain string) (string, bool) {
+	if name == domain {
+		return "@", true
+	}
+	if strings.HasSuffix(name, "."+domain) {
+		return strings.TrimSuffix(name, "."+domain), true
+	}
+	return "", false
+}
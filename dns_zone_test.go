@@ -0,0 +1,101 @@
+package civogo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testZoneFile = `$ORIGIN example.com.
+$TTL 1h
+@       IN  SOA ns1.example.com. admin.example.com. (
+            2026101501 ; serial
+            7200       ; refresh
+            3600 1209600 3600 )
+        IN  NS  ns1.example.com.
+@       IN  A     10.0.0.1
+www     300 IN CNAME @
+ipv6        AAAA  2001:db8::1
+mail.example.com. IN MX 10 mx1
+@           TXT   "v=spf1 include:_spf.example.com" " ~all" ; trailing comment
+_sip._tcp   SRV   10 60 5060 sip.example.com.
+broken      IN    MX  mx2
+`
+
+func TestParseZoneFile(t *testing.T) {
+	records, parseErrors, err := parseZoneFile(strings.NewReader(testZoneFile), "example.com")
+	if err != nil {
+		t.Errorf("Parsing returned an error: %s", err)
+		return
+	}
+
+	if len(parseErrors) != 1 || !strings.Contains(parseErrors[0].Error(), "line 14") {
+		t.Errorf("Expected one parse error for line 14, got %v", parseErrors)
+	}
+
+	got := make([]DNSRecordConfig, 0, len(records))
+	for _, r := range records {
+		got = append(got, r.config)
+	}
+	expected := []DNSRecordConfig{
+		{Type: "SOA", Name: "@", Value: "ns1.example.com. admin.example.com. 2026101501 7200 3600 1209600 3600", TTL: 3600},
+		{Type: "NS", Name: "@", Value: "ns1.example.com.", TTL: 3600},
+		{Type: DNSRecordTypeA, Name: "@", Value: "10.0.0.1", TTL: 3600},
+		{Type: DNSRecordTypeCName, Name: "www", Value: "example.com", TTL: 300},
+		{Type: DNSRecordTypeAAAA, Name: "ipv6", Value: "2001:db8::1", TTL: 3600},
+		{Type: DNSRecordTypeMX, Name: "mail", Value: "mx1.example.com", Priority: 10, TTL: 3600},
+		{Type: DNSRecordTypeTXT, Name: "@", Value: "v=spf1 include:_spf.example.com ~all", TTL: 3600},
+		{Type: DNSRecordTypeSRV, Name: "_sip._tcp", Value: "60 5060 sip.example.com", Priority: 10, TTL: 3600},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestImportDNSZone(t *testing.T) {
+	var posted []DNSRecordConfig
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/v2/dns":
+			rw.Write([]byte(`[{"id": "1111", "account_id": "1", "name": "example.com"}]`))
+		case req.Method == "GET" && req.URL.Path == "/v2/dns/1111/records":
+			rw.Write([]byte(`[{"id": "12345", "domain_id": "1111", "name": "@", "type": "A", "value": "10.0.0.1", "ttl": 3600}]`))
+		case req.Method == "POST" && req.URL.Path == "/v2/dns/1111/records":
+			var config DNSRecordConfig
+			json.NewDecoder(req.Body).Decode(&config)
+			if config.Type == DNSRecordTypeSRV {
+				rw.WriteHeader(http.StatusBadRequest)
+				rw.Write([]byte(`{"code":"database_dns_record_create","reason":"invalid record"}`))
+				return
+			}
+			posted = append(posted, config)
+			record, _ := json.Marshal(DNSRecord{ID: "new", DNSDomainID: "1111", Name: config.Name, Type: config.Type, Value: config.Value, Priority: config.Priority, TTL: config.TTL})
+			rw.Write(record)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.ImportDNSZone("1111", strings.NewReader(testZoneFile))
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+
+	// SOA, NS and the existing A record are skipped, the SRV record and the broken MX line fail
+	if got.Created != 4 || got.Skipped != 3 || got.Failed != 2 || len(got.Errors) != 2 {
+		t.Errorf("Expected 4 created, 3 skipped and 2 failed, got %+v", got)
+	}
+	if len(posted) != 4 || posted[0].Name != "www" || posted[3].Type != DNSRecordTypeTXT {
+		t.Errorf("Expected the CNAME, AAAA, MX and TXT records to be created, got %+v", posted)
+		return
+	}
+	if posted[0].TTL != DefaultDNSMinimumTTL {
+		t.Errorf("Expected the CNAME's TTL to be raised to %d, got %d", DefaultDNSMinimumTTL, posted[0].TTL)
+	}
+}
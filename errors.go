@@ -1,24 +1,48 @@
 package civogo
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // Errors raised by package civogo
 var (
-	ResponseDecodeFailedError = constError("ResponseDecodeFailed")
-	DisabledServiceError      = constError("DisabledServiceError")
-	NoAPIKeySuppliedError     = constError("NoAPIKeySuppliedError")
-	MultipleMatchesError      = constError("MultipleMatchesError")
-	ZeroMatchesError          = constError("ZeroMatchesError")
-	IDisEmptyError            = constError("IDisEmptyError")
-	TimeoutError              = constError("TimeoutError")
-	RegionUnavailableError    = constError("RegionUnavailable")
+	ResponseDecodeFailedError   = constError("ResponseDecodeFailed")
+	DisabledServiceError        = constError("DisabledServiceError")
+	NoAPIKeySuppliedError       = constError("NoAPIKeySuppliedError")
+	MultipleMatchesError        = constError("MultipleMatchesError")
+	ZeroMatchesError            = constError("ZeroMatchesError")
+	IDisEmptyError              = constError("IDisEmptyError")
+	TimeoutError                = constError("TimeoutError")
+	RegionUnavailableError      = constError("RegionUnavailable")
+	RegionUnknownFeatureError   = constError("RegionUnknownFeatureError")
+	RequestCanceledError        = constError("RequestCanceledError")
+	DiskImageFailedError        = constError("DiskImageFailedError")
+	DiskImageUploadFailedError  = constError("DiskImageUploadFailedError")
+	DiskImageTerminalStateError = constError("DiskImageTerminalStateError")
+	DiskImageChecksumError      = constError("DiskImageChecksumError")
+
+	// StopIterationError can be returned by the callback given to an Each helper to stop early
+	// without EachX returning an error
+	StopIterationError = constError("StopIterationError")
+
+	// Instance Error
+	InstanceNotActiveError      = constError("InstanceNotActiveError")
+	InstanceSizeDowngradeError  = constError("InstanceSizeDowngradeError")
+	InstanceFailedError         = constError("InstanceFailedError")
+	InstanceTagInvalidError     = constError("InstanceTagInvalidError")
+	InstanceNoFirewallError     = constError("InstanceNoFirewallError")
+	InstanceScriptTooLargeError = constError("InstanceScriptTooLargeError")
+	InstanceAlreadyInStateError = constError("InstanceAlreadyInStateError")
+	InstanceNoIPError           = constError("InstanceNoIPError")
+	InstanceNoRootVolumeError   = constError("InstanceNoRootVolumeError")
 
 	CivoStatsdRecordFailedError = constError("CivoStatsdRecordFailedError")
 	AuthenticationFailedError   = constError("AuthenticationFailedError")
@@ -29,6 +53,46 @@ var (
 	CannotRestoreNewVolumeError             = constError("CannotRestoreNewVolumeError")
 	CannotScaleAlreadyRescalingClusterError = constError("CannotScaleAlreadyRescalingClusterError")
 	VolumeInvalidSizeError                  = constError("VolumeInvalidSizeError")
+	VolumeAttachedElsewhereError            = constError("VolumeAttachedElsewhereError")
+	VolumeNotAvailableError                 = constError("VolumeNotAvailableError")
+	VolumeSnapshotFailedError               = constError("VolumeSnapshotFailedError")
+	VolumeFailedError                       = constError("VolumeFailedError")
+
+	FirewallRuleCreateFailedError = constError("FirewallRuleCreateFailedError")
+	FirewallRuleOrderInvalidError = constError("FirewallRuleOrderInvalidError")
+
+	ReservedIPNotAssignedToInstanceError = constError("ReservedIPNotAssignedToInstanceError")
+
+	NetworkCIDROverlapError = constError("NetworkCIDROverlapError")
+
+	LoadBalancerDuplicateBackendError = constError("LoadBalancerDuplicateBackendError")
+
+	SSHKeyInvalidError = constError("SSHKeyInvalidError")
+
+	TeamPermissionInvalidError = constError("TeamPermissionInvalidError")
+
+	WebhookSignatureInvalidError = constError("WebhookSignatureInvalidError")
+	WebhookEventInvalidError     = constError("WebhookEventInvalidError")
+
+	ObjectStoreRequestFailedError = constError("ObjectStoreRequestFailedError")
+
+	ResourceSnapshotNotInstanceError  = constError("ResourceSnapshotNotInstanceError")
+	ResourceSnapshotNotCompletedError = constError("ResourceSnapshotNotCompletedError")
+
+	SnapshotScheduleInvalidCronError      = constError("SnapshotScheduleInvalidCronError")
+	SnapshotScheduleInvalidRetentionError = constError("SnapshotScheduleInvalidRetentionError")
+
+	KubernetesClusterNotActiveError        = constError("KubernetesClusterNotActiveError")
+	KubernetesClusterKubeconfigEmptyError  = constError("KubernetesClusterKubeconfigEmptyError")
+	KubernetesClusterInvalidUpgradeError   = constError("KubernetesClusterInvalidUpgradeError")
+	KubernetesApplicationNotFoundError     = constError("KubernetesApplicationNotFoundError")
+	KubernetesApplicationNotInstalledError = constError("KubernetesApplicationNotInstalledError")
+	KubernetesClusterLastPoolError         = constError("KubernetesClusterLastPoolError")
+
+	DatabaseEngineUnsupportedError = constError("DatabaseEngineUnsupportedError")
+	DatabaseFailedError            = constError("DatabaseFailedError")
+	DatabaseNotReadyError          = constError("DatabaseNotReadyError")
+	DatabaseNoAddressError         = constError("DatabaseNoAddressError")
 
 	DatabaseAccountDestroyError      = constError("DatabaseAccountDestroyError")
 	DatabaseAccountNotFoundError     = constError("DatabaseAccountNotFoundError")
@@ -233,6 +297,7 @@ var (
 	ParameterDateRangeTooLongError          = constError("ParameterDateRangeTooLongError")
 	ParameterDNSRecordTypeError             = constError("ParameterDnsRecordTypeError")
 	ParameterDNSRecordCnameApexError        = constError("ParameterDNSRecordCnameApexError")
+	ParameterDNSRecordTTLError              = constError("ParameterDNSRecordTTLError")
 	ParameterPublicKeyEmptyError            = constError("ParameterPublicKeyEmptyError")
 	ParameterDateRangeError                 = constError("ParameterDateRangeError")
 	ParameterIDMissingError                 = constError("ParameterIDMissingError")
@@ -251,6 +316,8 @@ var (
 	ParameterStartPortMissingError          = constError("ParameterStartPortMissingError")
 	DatabaseTemplateParseRequestError       = constError("DatabaseTemplateParseRequestError")
 	ParameterValueMissingError              = constError("ParameterValueMissingError")
+	ParameterChecksumInvalidError           = constError("ParameterChecksumInvalidError")
+	ParameterURLInvalidError                = constError("ParameterURLInvalidError")
 
 	OutOFCapacityError                           = constError("OutOFCapacityError")
 	CannotGetConsoleError                        = constError("CannotGetConsoleError")
@@ -277,7 +344,9 @@ var (
 	AccountNotEnabledIncCardError     = constError("AccountNotEnabledIncCardError")
 	AccountNotEnabledWithoutCardError = constError("AccountNotEnabledWithoutCardError")
 
-	UnknownError        = constError("UnknownError")
+	UnknownError = constError("UnknownError")
+	// AuthenticationError is returned when the API rejects the API key, including any 401 or 403
+	// response without a more specific error code
 	AuthenticationError = constError("AuthenticationError")
 	InternalServerError = constError("InternalServerError")
 )
@@ -318,12 +387,100 @@ func (err wrapError) Is(target error) bool {
 	return constError(err.msg).Is(target)
 }
 
+// httpErrorCause is the cause of an error decoded from an HTTPError, it reads as the decoded
+// message but keeps the HTTPError in the chain for errors.As
+type httpErrorCause struct {
+	err     error
+	httpErr HTTPError
+}
+
+func (e httpErrorCause) Error() string {
+	return e.err.Error()
+}
+
+func (e httpErrorCause) Unwrap() []error {
+	return []error{e.err, e.httpErr}
+}
+
+// RateLimitError is returned when the API throttles a request with a 429 response. RetryAfter is
+// how long the API asked callers to wait before trying again, zero if it didn't say. The
+// HTTPError for the response is kept in the chain for errors.As
+type RateLimitError struct {
+	RetryAfter time.Duration
+	HTTPError  HTTPError
+}
+
+func (e RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("RateLimitError: too many requests, retry after %s", e.RetryAfter)
+	}
+	return "RateLimitError: too many requests"
+}
+
+func (e RateLimitError) Unwrap() error {
+	return e.HTTPError
+}
+
+// MatchCandidate is one of the items a Find helper's search matched
+type MatchCandidate struct {
+	ID   string
+	Name string
+}
+
+// MatchAmbiguityError is the cause of the MultipleMatchesError a Find helper returns when search
+// partly matches more than one item. Candidates lists those items so callers can offer a choice,
+// extract it with errors.As
+type MatchAmbiguityError struct {
+	Search     string
+	Candidates []MatchCandidate
+}
+
+func (e *MatchAmbiguityError) Error() string {
+	return fmt.Sprintf("unable to find %s because there were multiple matches", e.Search)
+}
+
+// decodeError turns an error from the API into one of the typed errors above. When it came from
+// an HTTP error response, the HTTPError is kept in the chain with its APIReason filled in
 func decodeError(err error) error {
+	decoded := decodeErrorType(err)
+
+	httpErr, ok := err.(HTTPError)
+	if !ok {
+		return decoded
+	}
+
+	var response struct {
+		Reason  string `json:"reason"`
+		Details string `json:"details"`
+	}
+	if json.Unmarshal([]byte(httpErr.Reason), &response) == nil {
+		httpErr.APIReason = response.Reason
+		if response.Details != "" {
+			httpErr.APIReason += ", " + response.Details
+		}
+	}
+
+	if httpErr.Code == http.StatusTooManyRequests {
+		return RateLimitError{RetryAfter: httpErr.RetryAfter, HTTPError: httpErr}
+	}
+
+	wrapped, ok := decoded.(wrapError)
+	if !ok {
+		return decoded
+	}
+	wrapped.err = httpErrorCause{err: wrapped.err, httpErr: httpErr}
+	return wrapped
+}
+
+func decodeErrorType(err error) error {
 	var response map[string]interface{}
 	var msg strings.Builder
 
 	switch err := err.(type) {
 	case *url.Error:
+		if errors.Is(err.Err, context.Canceled) || errors.Is(err.Err, context.DeadlineExceeded) {
+			return RequestCanceledError.wrap(err.Err)
+		}
 		if _, ok := err.Err.(net.Error); ok {
 			err := fmt.Errorf("problem connecting to the API")
 			return TimeoutError.wrap(err)
@@ -344,6 +501,9 @@ func decodeError(err error) error {
 		reason := []byte(errorData.Reason)
 
 		if err := json.Unmarshal(reason, &response); err != nil {
+			if isAuthenticationStatus(errorData.Code) {
+				return authenticationError(errorData)
+			}
 			err := fmt.Errorf("failed to decode the response expected from the API - status: %s, code: %d, reason: %s", errorData.Status, errorData.Code, errorData.Reason)
 			return ResponseDecodeFailedError.wrap(err)
 		}
@@ -1060,6 +1220,9 @@ func decodeError(err error) error {
 			err := errors.New(msg.String())
 			return KubernetesClusterInvalidNameError.wrap(err)
 		default:
+			if isAuthenticationStatus(errorData.Code) {
+				return authenticationError(errorData)
+			}
 			err := fmt.Errorf(fmt.Sprintf("Unknown error response - status: %s, code: %d, reason: %s", errorData.Status, errorData.Code, errorData.Reason))
 			return CommonError.wrap(err)
 		}
@@ -1067,3 +1230,15 @@ func decodeError(err error) error {
 
 	return UnknownError.wrap(err)
 }
+
+// isAuthenticationStatus reports whether an HTTP status means the API key was rejected
+func isAuthenticationStatus(code int) bool {
+	return code == http.StatusUnauthorized || code == http.StatusForbidden
+}
+
+// authenticationError is the AuthenticationError returned for a 401 or 403 response without a
+// more specific error code
+func authenticationError(errorData HTTPError) error {
+	err := fmt.Errorf("the API key is invalid or expired - status: %s, reason: %s", errorData.Status, errorData.Reason)
+	return AuthenticationError.wrap(err)
+}
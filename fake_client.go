@@ -152,9 +152,7 @@ type Clienter interface {
 	// DeleteTemplate(id string) (*SimpleResponse, error)
 
 	// DiskImages
-	ListDiskImages(includeCustom ...bool) ([]DiskImage, error)
-	GetDiskImage(id string) (*DiskImage, error)
-	FindDiskImage(search string) (*DiskImage, error)
+	DiskImageService
 
 	// Volumes
 	ListVolumes() ([]Volume, error)
@@ -300,6 +298,14 @@ func (c *FakeClient) generateID() string {
 	return strconv.FormatInt(c.LastID, 10)
 }
 
+func (c *FakeClient) generateUUID() string {
+	r := rand.New(rand.NewSource(time.Now().UnixNano() + c.LastID))
+	c.LastID++
+	b := make([]byte, 16)
+	r.Read(b)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
 func (c *FakeClient) generatePublicIP() string {
 	s := rand.NewSource(time.Now().UnixNano())
 	r := rand.New(s)
@@ -819,7 +825,7 @@ func (c *FakeClient) NewKubernetesClusters(kc *KubernetesClusterConfig) (*Kubern
 		NumTargetNode:  kc.NumTargetNodes,
 		TargetNodeSize: kc.TargetNodesSize,
 		Ready:          true,
-		Status:         "ACTIVE",
+		Status:         KubernetesClusterStatusActive,
 		Instances:      make([]KubernetesInstance, 0),
 		Pools:          make([]KubernetesPool, 0),
 	}
@@ -1240,6 +1246,100 @@ func (c *FakeClient) FindDiskImage(search string) (*DiskImage, error) {
 	return nil, ZeroMatchesError.wrap(err)
 }
 
+// GetDiskImageByName implemented in a fake way for automated tests
+func (c *FakeClient) GetDiskImageByName(name string) (*DiskImage, error) {
+	for k, v := range c.DiskImage {
+		if v.Name == name {
+			return &c.DiskImage[k], nil
+		}
+	}
+
+	err := fmt.Errorf("unable to find disk image %s, zero matches", name)
+	return nil, ZeroMatchesError.wrap(err)
+}
+
+// GetMostRecentDistro implemented in a fake way for automated tests
+func (c *FakeClient) GetMostRecentDistro(name string) (*DiskImage, error) {
+	var highestVersionDistro *DiskImage
+	for k, v := range c.DiskImage {
+		if strings.Contains(v.Name, name) {
+			if highestVersionDistro == nil || compareDistroVersions(highestVersionDistro.Version, v.Version) < 0 {
+				highestVersionDistro = &c.DiskImage[k]
+			}
+		}
+	}
+	if highestVersionDistro == nil {
+		return nil, fmt.Errorf("%s image not found", name)
+	}
+
+	return highestVersionDistro, nil
+}
+
+// CreateDiskImage implemented in a fake way for automated tests
+func (c *FakeClient) CreateDiskImage(params *CreateDiskImageParams) (*CreateDiskImageResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	diskImage := DiskImage{
+		ID:                 c.generateUUID(),
+		Name:               params.Name,
+		Version:            params.Version,
+		State:              "available",
+		InitialUser:        params.InitialUser,
+		Distribution:       params.Distribution,
+		OS:                 params.OS,
+		DiskImageSizeBytes: params.ImageSizeBytes,
+		CreatedAt:          time.Now(),
+	}
+	c.DiskImage = append(c.DiskImage, diskImage)
+
+	return &CreateDiskImageResponse{
+		ID:                 diskImage.ID,
+		Name:               diskImage.Name,
+		Distribution:       diskImage.Distribution,
+		Version:            diskImage.Version,
+		OS:                 diskImage.OS,
+		Region:             params.Region,
+		Status:             diskImage.State,
+		InitialUser:        diskImage.InitialUser,
+		DiskImageURL:       fmt.Sprintf("https://example.com/upload/%s", diskImage.ID),
+		DiskImageSizeBytes: diskImage.DiskImageSizeBytes,
+		ImageSize:          diskImage.DiskImageSizeBytes,
+		CreatedAt:          diskImage.CreatedAt,
+	}, nil
+}
+
+// UpdateDiskImage implemented in a fake way for automated tests
+func (c *FakeClient) UpdateDiskImage(id string, params *UpdateDiskImageParams) (*DiskImage, error) {
+	diskImage, err := c.GetDiskImage(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Label != nil {
+		diskImage.Label = *params.Label
+	}
+	if params.Description != nil {
+		diskImage.Description = *params.Description
+	}
+
+	return diskImage, nil
+}
+
+// DeleteDiskImage implemented in a fake way for automated tests
+func (c *FakeClient) DeleteDiskImage(id string) error {
+	for i, v := range c.DiskImage {
+		if v.ID == id {
+			c.DiskImage = append(c.DiskImage[:i], c.DiskImage[i+1:]...)
+			return nil
+		}
+	}
+
+	err := fmt.Errorf("unable to find disk image %s, zero matches", id)
+	return ZeroMatchesError.wrap(err)
+}
+
 // ListVolumes implemented in a fake way for automated tests
 func (c *FakeClient) ListVolumes() ([]Volume, error) {
 	return c.Volumes, nil
@@ -1275,7 +1375,7 @@ func (c *FakeClient) NewVolume(v *VolumeConfig) (*VolumeResult, error) {
 		ID:            c.generateID(),
 		Name:          v.Name,
 		SizeGigabytes: v.SizeGigabytes,
-		Status:        "available",
+		Status:        VolumeStatusAvailable,
 	}
 	c.Volumes = append(c.Volumes, volume)
 
@@ -1318,7 +1418,7 @@ func (c *FakeClient) DetachVolume(id string) (*SimpleResponse, error) {
 	for i, volume := range c.Volumes {
 		if volume.ID == id {
 			c.Volumes[i].InstanceID = ""
-			c.Volumes[i].Status = "available"
+			c.Volumes[i].Status = VolumeStatusAvailable
 			return &SimpleResponse{Result: "success"}, nil
 		}
 	}
@@ -284,3 +284,42 @@ func TestPing(t *testing.T) {
 		t.Errorf("Expected nil, got '%v'", err)
 	}
 }
+
+func TestDiskImages(t *testing.T) {
+	g := NewWithT(t)
+
+	client, err := NewFakeClient()
+	g.Expect(err).To(BeNil())
+
+	created, err := client.CreateDiskImage(&CreateDiskImageParams{
+		Name:           "custom-ubuntu",
+		Distribution:   "ubuntu",
+		Version:        "22.04",
+		Source:         "upload",
+		ImageSHA256:    "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		ImageMD5:       "d41d8cd98f00b204e9800998ecf8427e",
+		ImageSizeBytes: 1073741824,
+	})
+	g.Expect(err).To(BeNil())
+	g.Expect(created.ID).To(HaveLen(36))
+	g.Expect(created.Status).To(Equal("available"))
+
+	diskImage, err := client.GetDiskImage(created.ID)
+	g.Expect(err).To(BeNil())
+	g.Expect(diskImage.Name).To(Equal("custom-ubuntu"))
+	g.Expect(diskImage.State).To(Equal("available"))
+
+	diskImage, err = client.GetDiskImageByName("custom-ubuntu")
+	g.Expect(err).To(BeNil())
+	g.Expect(diskImage.ID).To(Equal(created.ID))
+
+	diskImage, err = client.GetMostRecentDistro("ubuntu")
+	g.Expect(err).To(BeNil())
+	g.Expect(diskImage.ID).To(Equal(created.ID))
+
+	err = client.DeleteDiskImage(created.ID)
+	g.Expect(err).To(BeNil())
+
+	_, err = client.GetDiskImage(created.ID)
+	g.Expect(errors.Is(err, ZeroMatchesError)).To(BeTrue())
+}
@@ -0,0 +1,36 @@
+package civogo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// findMatch is the matching shared by the Find helpers. It returns the item whose ID or name is
+// exactly search, wherever it is in items, otherwise the only item whose ID or name contains
+// search. If more than one item partly matches, the MultipleMatchesError wraps a
+// MatchAmbiguityError listing them. keys returns the ID and name of an item
+func findMatch[T any](items []T, search string, keys func(T) (id, name string)) (*T, error) {
+	var result T
+	candidates := []MatchCandidate{}
+
+	for _, value := range items {
+		id, name := keys(value)
+		if name == search || id == search {
+			return &value, nil
+		}
+		if strings.Contains(name, search) || strings.Contains(id, search) {
+			result = value
+			candidates = append(candidates, MatchCandidate{ID: id, Name: name})
+		}
+	}
+
+	if len(candidates) == 1 {
+		return &result, nil
+	} else if len(candidates) > 1 {
+		err := &MatchAmbiguityError{Search: search, Candidates: candidates}
+		return nil, MultipleMatchesError.wrap(err)
+	}
+
+	err := fmt.Errorf("unable to find %s, zero matches", search)
+	return nil, ZeroMatchesError.wrap(err)
+}
@@ -39,6 +39,7 @@ type FirewallRule struct {
 	Action     string   `json:"action"`
 	Label      string   `json:"label,omitempty"`
 	Ports      string   `json:"ports,omitempty"`
+	Order      int      `json:"order,omitempty"`
 }
 
 // FirewallRuleConfig is how you specify the details when creating a new rule
@@ -54,6 +55,9 @@ type FirewallRuleConfig struct {
 	Label      string   `json:"label,omitempty"`
 	// Ports will be chosen over StartPort,EndPort if both are provided
 	Ports string `json:"ports,omitempty"`
+	// Order is where the rule is evaluated among the firewall's rules, lowest first; left to the
+	// API if not set
+	Order int `json:"order,omitempty"`
 }
 
 // FirewallConfig is how you specify the details when creating a new firewall
@@ -173,6 +177,118 @@ func (c *Client) NewFirewallRule(r *FirewallRuleConfig) (*FirewallRule, error) {
 	return rule, nil
 }
 
+// NewFirewallRules creates each of the rules within the firewall, returning the created rules in
+// order. It stops at the first rule that fails, and the error says which index that was. When
+// allOrNothing is true the rules already created are deleted again, so the firewall is left as it was
+func (c *Client) NewFirewallRules(firewallID string, rules []FirewallRuleConfig, allOrNothing ...bool) ([]*FirewallRule, error) {
+	if len(firewallID) == 0 {
+		err := fmt.Errorf("the firewall ID is empty")
+		return nil, IDisEmptyError.wrap(err)
+	}
+
+	created := make([]*FirewallRule, 0, len(rules))
+	for i := range rules {
+		r := rules[i]
+		r.FirewallID = firewallID
+
+		rule, err := c.NewFirewallRule(&r)
+		if err == nil {
+			created = append(created, rule)
+			continue
+		}
+
+		err = fmt.Errorf("rule %d of %d failed: %w", i, len(rules), err)
+		if len(allOrNothing) == 0 || !allOrNothing[0] {
+			return created, FirewallRuleCreateFailedError.wrap(err)
+		}
+
+		for _, rule := range created {
+			if _, deleteErr := c.DeleteFirewallRule(firewallID, rule.ID); deleteErr != nil {
+				err = fmt.Errorf("%w, and rolling back rule %s failed: %v", err, rule.ID, deleteErr)
+			}
+		}
+		return nil, FirewallRuleCreateFailedError.wrap(err)
+	}
+
+	return created, nil
+}
+
+// NewFirewallRuleIfNotExists creates a new rule within a firewall, unless the firewall already
+// has an equivalent rule (same protocol, ports, CIDRs, direction and action), in which case the
+// existing rule is returned instead
+func (c *Client) NewFirewallRuleIfNotExists(r *FirewallRuleConfig) (*FirewallRule, error) {
+	if len(r.FirewallID) == 0 {
+		err := fmt.Errorf("the firewall ID is empty")
+		return nil, IDisEmptyError.wrap(err)
+	}
+
+	rules, err := c.ListFirewallRules(r.FirewallID)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, rule := range rules {
+		if isEquivalentFirewallRule(rule, r) {
+			return &rules[k], nil
+		}
+	}
+
+	return c.NewFirewallRule(r)
+}
+
+// isEquivalentFirewallRule checks if an existing rule has the same effect as the rule config
+func isEquivalentFirewallRule(rule FirewallRule, r *FirewallRuleConfig) bool {
+	ruleStart, ruleEnd := firewallRulePortRange(rule.StartPort, rule.EndPort, rule.Ports)
+	configStart, configEnd := firewallRulePortRange(r.StartPort, r.EndPort, r.Ports)
+
+	if !strings.EqualFold(rule.Protocol, r.Protocol) ||
+		ruleStart != configStart ||
+		ruleEnd != configEnd ||
+		!strings.EqualFold(rule.Direction, r.Direction) ||
+		!strings.EqualFold(firewallRuleAction(rule.Action), firewallRuleAction(r.Action)) ||
+		len(rule.Cidr) != len(r.Cidr) {
+		return false
+	}
+
+	cidrs := make(map[string]int, len(rule.Cidr))
+	for _, cidr := range rule.Cidr {
+		cidrs[cidr]++
+	}
+	for _, cidr := range r.Cidr {
+		if cidrs[cidr] == 0 {
+			return false
+		}
+		cidrs[cidr]--
+	}
+
+	return true
+}
+
+// firewallRulePortRange returns the start and end port a rule covers. Ports wins over
+// startPort/endPort, as it does in the API, and a single port is a range ending where it starts.
+// A comma separated list of ports isn't a range, so it's returned as is, as the start
+func firewallRulePortRange(startPort, endPort, ports string) (string, string) {
+	if ports = strings.ReplaceAll(ports, " ", ""); ports != "" {
+		if strings.Contains(ports, ",") {
+			return ports, ""
+		}
+		startPort, endPort, _ = strings.Cut(ports, "-")
+	}
+
+	if endPort == "" {
+		endPort = startPort
+	}
+	return startPort, endPort
+}
+
+// firewallRuleAction returns the action of a rule, which the API takes to be allow if it's not set
+func firewallRuleAction(action string) string {
+	if action == "" {
+		return "allow"
+	}
+	return action
+}
+
 // ListFirewallRules get all rules for a firewall
 func (c *Client) ListFirewallRules(id string) ([]FirewallRule, error) {
 	resp, err := c.SendGetRequest(fmt.Sprintf("/v2/firewalls/%s/rules", id))
@@ -270,3 +386,49 @@ func areDefaultRules(rules []FirewallRule, defaultRules []FirewallRule) bool {
 
 	return true
 }
+
+// ReorderFirewallRules sets the order the firewall's rules are evaluated in, which matters when
+// allow and deny rules overlap. orderedRuleIDs must list every existing rule exactly once,
+// otherwise a FirewallRuleOrderInvalidError is returned and nothing is changed; the first ID gets
+// Order 1, the next 2 and so on
+func (c *Client) ReorderFirewallRules(firewallID string, orderedRuleIDs []string) error {
+	if len(firewallID) == 0 {
+		err := fmt.Errorf("the firewall ID is empty")
+		return IDisEmptyError.wrap(err)
+	}
+
+	rules, err := c.ListFirewallRules(firewallID)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		existing[rule.ID] = true
+	}
+	seen := make(map[string]bool, len(orderedRuleIDs))
+	for _, id := range orderedRuleIDs {
+		if !existing[id] {
+			err := fmt.Errorf("rule %s isn't in firewall %s", id, firewallID)
+			return FirewallRuleOrderInvalidError.wrap(err)
+		}
+		if seen[id] {
+			err := fmt.Errorf("rule %s is listed more than once", id)
+			return FirewallRuleOrderInvalidError.wrap(err)
+		}
+		seen[id] = true
+	}
+	if len(seen) != len(existing) {
+		err := fmt.Errorf("%d rules were ordered, but firewall %s has %d", len(seen), firewallID, len(existing))
+		return FirewallRuleOrderInvalidError.wrap(err)
+	}
+
+	for i, id := range orderedRuleIDs {
+		data := map[string]interface{}{"order": i + 1, "region": c.Region}
+		if _, err := c.SendPutRequest(fmt.Sprintf("/v2/firewalls/%s/rules/%s", firewallID, id), data); err != nil {
+			return decodeError(err)
+		}
+	}
+
+	return nil
+}
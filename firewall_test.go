@@ -1,7 +1,13 @@
 package civogo
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -379,3 +385,186 @@ func TestDeleteFirewallRule(t *testing.T) {
 		t.Errorf("Expected %+v, got %+v", expected, got)
 	}
 }
+
+func TestNewFirewallRuleIfNotExists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "POST" {
+			rw.Write([]byte(`{"id":"2","firewall_id":"78901","protocol":"tcp","start_port":"443","end_port":"443","cidr":["0.0.0.0/0"],"direction":"ingress","action":"allow"}`))
+			return
+		}
+		rw.Write([]byte(`[{"id":"1","firewall_id":"78901","protocol":"tcp","start_port":"22","end_port":"22","cidr":["10.0.0.0/8","0.0.0.0/0"],"direction":"ingress","action":"allow"}]`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	existing, err := client.NewFirewallRuleIfNotExists(&FirewallRuleConfig{
+		FirewallID: "78901",
+		Protocol:   "TCP",
+		StartPort:  "22",
+		EndPort:    "22",
+		Cidr:       []string{"0.0.0.0/0", "10.0.0.0/8"},
+		Direction:  "ingress",
+		Action:     "allow",
+	})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if existing.ID != "1" {
+		t.Errorf("Expected the existing rule %s, got %s", "1", existing.ID)
+	}
+
+	created, err := client.NewFirewallRuleIfNotExists(&FirewallRuleConfig{
+		FirewallID: "78901",
+		Protocol:   "tcp",
+		StartPort:  "443",
+		EndPort:    "443",
+		Cidr:       []string{"0.0.0.0/0"},
+		Direction:  "ingress",
+		Action:     "allow",
+	})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if created.ID != "2" {
+		t.Errorf("Expected the new rule %s, got %s", "2", created.ID)
+	}
+}
+
+func TestIsEquivalentFirewallRule(t *testing.T) {
+	rule := FirewallRule{Protocol: "tcp", StartPort: "80", EndPort: "443", Cidr: []string{"0.0.0.0/0"}, Direction: "ingress", Action: "allow"}
+	single := FirewallRule{Protocol: "tcp", StartPort: "22", EndPort: "22", Cidr: []string{"0.0.0.0/0"}, Direction: "ingress", Action: "allow"}
+	deny := FirewallRule{Protocol: "tcp", StartPort: "22", EndPort: "22", Cidr: []string{"0.0.0.0/0"}, Direction: "ingress", Action: "deny"}
+
+	tests := []struct {
+		name     string
+		rule     FirewallRule
+		config   FirewallRuleConfig
+		expected bool
+	}{
+		{"same range", rule, FirewallRuleConfig{Protocol: "tcp", StartPort: "80", EndPort: "443", Cidr: []string{"0.0.0.0/0"}, Direction: "ingress", Action: "allow"}, true},
+		{"ports range", rule, FirewallRuleConfig{Protocol: "tcp", Ports: "80-443", Cidr: []string{"0.0.0.0/0"}, Direction: "ingress", Action: "allow"}, true},
+		{"ports range with spaces", rule, FirewallRuleConfig{Protocol: "tcp", Ports: "80 - 443", Cidr: []string{"0.0.0.0/0"}, Direction: "ingress", Action: "allow"}, true},
+		{"ports different range", rule, FirewallRuleConfig{Protocol: "tcp", Ports: "80-8080", Cidr: []string{"0.0.0.0/0"}, Direction: "ingress", Action: "allow"}, false},
+		{"ports single", single, FirewallRuleConfig{Protocol: "tcp", Ports: "22", Cidr: []string{"0.0.0.0/0"}, Direction: "ingress", Action: "allow"}, true},
+		{"start port only", single, FirewallRuleConfig{Protocol: "tcp", StartPort: "22", Cidr: []string{"0.0.0.0/0"}, Direction: "ingress", Action: "allow"}, true},
+		{"ports win over start and end", single, FirewallRuleConfig{Protocol: "tcp", StartPort: "80", EndPort: "80", Ports: "22", Cidr: []string{"0.0.0.0/0"}, Direction: "ingress", Action: "allow"}, true},
+		{"ports list", single, FirewallRuleConfig{Protocol: "tcp", Ports: "22,80", Cidr: []string{"0.0.0.0/0"}, Direction: "ingress", Action: "allow"}, false},
+		{"allow against deny", deny, FirewallRuleConfig{Protocol: "tcp", StartPort: "22", EndPort: "22", Cidr: []string{"0.0.0.0/0"}, Direction: "ingress", Action: "allow"}, false},
+		{"deny against deny", deny, FirewallRuleConfig{Protocol: "tcp", StartPort: "22", EndPort: "22", Cidr: []string{"0.0.0.0/0"}, Direction: "ingress", Action: "DENY"}, true},
+		{"default action", single, FirewallRuleConfig{Protocol: "tcp", StartPort: "22", EndPort: "22", Cidr: []string{"0.0.0.0/0"}, Direction: "ingress"}, true},
+		{"default action against deny", deny, FirewallRuleConfig{Protocol: "tcp", StartPort: "22", EndPort: "22", Cidr: []string{"0.0.0.0/0"}, Direction: "ingress"}, false},
+	}
+	for _, test := range tests {
+		if got := isEquivalentFirewallRule(test.rule, &test.config); got != test.expected {
+			t.Errorf("%s: expected %t, got %t", test.name, test.expected, got)
+		}
+	}
+}
+
+func newFirewallRulesTestServer(failPort string, deleted *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "DELETE" {
+			*deleted = append(*deleted, strings.TrimPrefix(req.URL.Path, "/v2/firewalls/78901/rules/"))
+			rw.Write([]byte(`{"result":"success"}`))
+			return
+		}
+
+		var config FirewallRuleConfig
+		json.NewDecoder(req.Body).Decode(&config)
+		if config.StartPort == failPort {
+			rw.WriteHeader(http.StatusBadRequest)
+			rw.Write([]byte(`{"code":"database_firewall_rules_invalid_params","reason":"invalid port"}`))
+			return
+		}
+		rw.Write([]byte(`{"id":"rule-` + config.StartPort + `","firewall_id":"` + config.FirewallID + `","start_port":"` + config.StartPort + `"}`))
+	}))
+}
+
+func TestNewFirewallRules(t *testing.T) {
+	var deleted []string
+	server := newFirewallRulesTestServer("", &deleted)
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.NewFirewallRules("78901", []FirewallRuleConfig{
+		{Protocol: "tcp", StartPort: "22", EndPort: "22"},
+		{Protocol: "tcp", StartPort: "443", EndPort: "443"},
+	})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(got) != 2 || got[0].ID != "rule-22" || got[1].ID != "rule-443" || got[1].FirewallID != "78901" {
+		t.Errorf("Expected rule-22 and rule-443, got %+v", got)
+	}
+}
+
+func TestNewFirewallRulesFailure(t *testing.T) {
+	var deleted []string
+	server := newFirewallRulesTestServer("99999", &deleted)
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	rules := []FirewallRuleConfig{
+		{Protocol: "tcp", StartPort: "22", EndPort: "22"},
+		{Protocol: "tcp", StartPort: "443", EndPort: "443"},
+		{Protocol: "tcp", StartPort: "99999", EndPort: "99999"},
+	}
+
+	got, err := client.NewFirewallRules("78901", rules)
+	if !errors.Is(err, FirewallRuleCreateFailedError) || !strings.Contains(err.Error(), "rule 2 of 3") {
+		t.Errorf("Expected %v for rule 2, got %v", FirewallRuleCreateFailedError, err)
+	}
+	if len(got) != 2 || len(deleted) != 0 {
+		t.Errorf("Expected the 2 created rules to be kept, got %+v and deleted %+v", got, deleted)
+	}
+
+	got, err = client.NewFirewallRules("78901", rules, true)
+	if !errors.Is(err, FirewallRuleCreateFailedError) {
+		t.Errorf("Expected %v, got %v", FirewallRuleCreateFailedError, err)
+	}
+	if got != nil || !reflect.DeepEqual(deleted, []string{"rule-22", "rule-443"}) {
+		t.Errorf("Expected the 2 created rules to be rolled back, got %+v and deleted %+v", got, deleted)
+	}
+}
+
+func TestReorderFirewallRules(t *testing.T) {
+	var orders []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "PUT" {
+			body := map[string]interface{}{}
+			json.NewDecoder(req.Body).Decode(&body)
+			orders = append(orders, fmt.Sprintf("%s=%v", strings.TrimPrefix(req.URL.Path, "/v2/firewalls/78901/rules/"), body["order"]))
+			rw.Write([]byte(`{"result": "success"}`))
+			return
+		}
+		rw.Write([]byte(`[{"id": "rule-a"}, {"id": "rule-b"}, {"id": "rule-c"}]`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	if err := client.ReorderFirewallRules("78901", []string{"rule-c", "rule-a", "rule-b"}); err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	expected := []string{"rule-c=1", "rule-a=2", "rule-b=3"}
+	if !reflect.DeepEqual(orders, expected) {
+		t.Errorf("Expected %v, got %v", expected, orders)
+	}
+
+	orders = nil
+	for _, ids := range [][]string{
+		{"rule-a", "rule-b"},
+		{"rule-a", "rule-b", "rule-b"},
+		{"rule-a", "rule-b", "rule-d"},
+	} {
+		if err := client.ReorderFirewallRules("78901", ids); !errors.Is(err, FirewallRuleOrderInvalidError) {
+			t.Errorf("Expected %v for %v, got %v", FirewallRuleOrderInvalidError, ids, err)
+		}
+	}
+	if len(orders) != 0 {
+		t.Errorf("Expected no rules to be reordered, got %v", orders)
+	}
+}
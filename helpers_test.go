@@ -1,6 +1,8 @@
 package civogo
 
 import (
+	"encoding/json"
+	"reflect"
 	"testing"
 )
 
@@ -15,3 +17,35 @@ func EnsureSuccessfulSimpleResponse(t *testing.T, got *SimpleResponse, err error
 		t.Errorf("Expected %s, got %s", "success", got.Result)
 	}
 }
+
+// EnsureJSONRoundTrip marshals value, unmarshals the JSON into a new T and checks it's
+// unchanged, then that marshaling that again gives the same JSON. It returns the JSON
+func EnsureJSONRoundTrip[T any](t *testing.T, value T) string {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Errorf("Marshal returned an error: %s", err)
+		return ""
+	}
+
+	var decoded T
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Errorf("Unmarshal returned an error: %s", err)
+		return ""
+	}
+	if !reflect.DeepEqual(decoded, value) {
+		t.Errorf("Expected %+v, got %+v", value, decoded)
+	}
+
+	again, err := json.Marshal(decoded)
+	if err != nil {
+		t.Errorf("Marshal returned an error: %s", err)
+		return ""
+	}
+	if string(again) != string(data) {
+		t.Errorf("Expected %s, got %s", data, again)
+	}
+
+	return string(data)
+}
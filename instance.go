@@ -2,7 +2,10 @@ package civogo
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -58,6 +61,26 @@ type Instance struct {
 	PlacementRule            PlacementRule    `json:"placement_rule,omitempty"`
 }
 
+const (
+	// InstanceStatusActive is the Status of a running instance
+	InstanceStatusActive = "ACTIVE"
+
+	// InstanceStatusBuilding is the Status of an instance that's still being created
+	InstanceStatusBuilding = "BUILDING"
+
+	// InstanceStatusRebooting is the Status of an instance while it reboots
+	InstanceStatusRebooting = "REBOOTING"
+
+	// InstanceStatusShutoff is the Status of a stopped instance
+	InstanceStatusShutoff = "SHUTOFF"
+
+	// InstanceStatusError is the Status of an instance that has gone wrong
+	InstanceStatusError = "ERROR"
+
+	// InstanceStatusFailed is the Status of an instance that couldn't be created
+	InstanceStatusFailed = "FAILED"
+)
+
 //"cpu_cores":1,"ram_mb":2048,"disk_gb":25
 
 // InstanceConsole represents a link to a webconsole for an instances
@@ -108,6 +131,7 @@ type InstanceConfig struct {
 	InitialUser      string           `json:"initial_user"`
 	SSHKeyID         string           `json:"ssh_key_id"`
 	Script           string           `json:"script"`
+	EncodeScript     bool             `json:"-"` // base64 encode Script before sending it
 	Tags             []string         `json:"-"`
 	TagsList         string           `json:"tags"`
 	FirewallID       string           `json:"firewall_id"`
@@ -156,47 +180,25 @@ func (c *Client) ListAllInstances() ([]Instance, error) {
 	return instances.Items, nil
 }
 
-// FindInstance finds a instance by either part of the ID or part of the hostname
+// FindInstance finds an instance by its exact ID or hostname, or failing that by part of the ID
+// or part of the hostname, returning MultipleMatchesError if that matches more than one
 func (c *Client) FindInstance(search string) (*Instance, error) {
 	instances, err := c.ListAllInstances()
 	if err != nil {
 		return nil, decodeError(err)
 	}
 
-	exactMatch := false
-	partialMatchesCount := 0
-	result := Instance{}
-
-	for _, value := range instances {
-		if value.Hostname == search || value.ID == search {
-			exactMatch = true
-			result = value
-			break
-		} else if strings.Contains(value.Hostname, search) || strings.Contains(value.ID, search) {
-			if !exactMatch {
-				result = value
-				partialMatchesCount++
-				if partialMatchesCount > 1 {
-					break
-				}
-			}
-		}
-	}
-
-	if exactMatch || partialMatchesCount == 1 {
-		return &result, nil
-	} else if partialMatchesCount > 1 {
-		err := fmt.Errorf("unable to find %s because there were multiple matches", search)
-		return nil, MultipleMatchesError.wrap(err)
-	} else {
-		err := fmt.Errorf("unable to find %s, zero matches", search)
-		return nil, ZeroMatchesError.wrap(err)
-	}
+	return findMatch(instances, search, func(i Instance) (string, string) { return i.ID, i.Hostname })
 }
 
 // GetInstance returns a single Instance by its full ID
 func (c *Client) GetInstance(id string) (*Instance, error) {
-	resp, err := c.SendGetRequest("/v2/instances/" + id)
+	return c.GetInstanceWithContext(context.Background(), id)
+}
+
+// GetInstanceWithContext is GetInstance bound to ctx
+func (c *Client) GetInstanceWithContext(ctx context.Context, id string) (*Instance, error) {
+	resp, err := c.SendGetRequestWithContext(ctx, "/v2/instances/"+id)
 	if err != nil {
 		return nil, decodeError(err)
 	}
@@ -206,6 +208,39 @@ func (c *Client) GetInstance(id string) (*Instance, error) {
 	return &instance, err
 }
 
+// WaitForInstanceStatus polls the instance until its Status is status (e.g. "ACTIVE"), returning
+// the instance once it is. It gives up after timeout, or as soon as the instance reaches a failed
+// state. pollInterval optionally overrides the default of 5 seconds between checks
+func (c *Client) WaitForInstanceStatus(id, status string, timeout time.Duration, pollInterval ...time.Duration) (*Instance, error) {
+	return c.WaitForInstanceStatusWithContext(context.Background(), id, status, timeout, pollInterval...)
+}
+
+// WaitForInstanceStatusWithContext is WaitForInstanceStatus bound to ctx
+func (c *Client) WaitForInstanceStatusWithContext(ctx context.Context, id, status string, timeout time.Duration, pollInterval ...time.Duration) (*Instance, error) {
+	var instance *Instance
+	err := pollUntil(ctx, timeout, pollIntervalOrDefault(pollInterval), fmt.Sprintf("instance %s to be %s", id, status), func(ctx context.Context) (bool, error) {
+		var err error
+		instance, err = c.GetInstanceWithContext(ctx, id)
+		if err != nil {
+			return false, err
+		}
+
+		if instance.Status != status && (instance.Status == InstanceStatusError || instance.Status == InstanceStatusFailed) {
+			err := fmt.Errorf("instance %s is in the %s state", id, instance.Status)
+			return false, InstanceFailedError.wrap(err)
+		}
+		return instance.Status == status, nil
+	})
+	if err != nil {
+		if errors.Is(err, InstanceFailedError) {
+			return instance, err
+		}
+		return nil, err
+	}
+
+	return instance, nil
+}
+
 // NewInstanceConfig returns an initialized config for a new instance
 func (c *Client) NewInstanceConfig() (*InstanceConfig, error) {
 	network, err := c.GetDefaultNetwork()
@@ -230,10 +265,25 @@ func (c *Client) NewInstanceConfig() (*InstanceConfig, error) {
 	}, nil
 }
 
-// CreateInstance creates a new instance in the account
+// MaxInstanceScriptSize is the largest initialisation script (cloud-init user data) the API
+// accepts, in bytes, measured as sent (after base64 encoding if InstanceConfig.EncodeScript is set)
+const MaxInstanceScriptSize = 16 * 1024
+
+// CreateInstance creates a new instance in the account. A Script larger than
+// MaxInstanceScriptSize is rejected with an InstanceScriptTooLargeError before anything is sent
 func (c *Client) CreateInstance(config *InstanceConfig) (*Instance, error) {
 	config.TagsList = strings.Join(config.Tags, " ")
-	body, err := c.SendPostRequest("/v2/instances", config)
+
+	payload := *config
+	if payload.EncodeScript {
+		payload.Script = base64.StdEncoding.EncodeToString([]byte(payload.Script))
+	}
+	if len(payload.Script) > MaxInstanceScriptSize {
+		err := fmt.Errorf("the instance script is %d bytes, the maximum is %d bytes", len(payload.Script), MaxInstanceScriptSize)
+		return nil, InstanceScriptTooLargeError.wrap(err)
+	}
+
+	body, err := c.SendPostRequest("/v2/instances", &payload)
 	if err != nil {
 		return nil, decodeError(err)
 	}
@@ -246,6 +296,43 @@ func (c *Client) CreateInstance(config *InstanceConfig) (*Instance, error) {
 	return &instance, nil
 }
 
+// InstanceCreateResult is the outcome of creating one instance as part of CreateInstances
+type InstanceCreateResult struct {
+	Hostname string
+	Instance *Instance
+	Err      error
+}
+
+// CreateInstances creates an instance for each of params, a few at a time, returning a result
+// per config in the same order. If groupTag is given it's added to every instance's tags, so the
+// group can be found (or torn down) together. A failure to create one instance doesn't stop the
+// others; the error is only non-nil if every instance failed, and then joins their errors
+func (c *Client) CreateInstances(params []InstanceConfig, groupTag ...string) ([]InstanceCreateResult, error) {
+	results := make([]InstanceCreateResult, len(params))
+	forEachConcurrently(len(params), func(i int) {
+		config := params[i]
+		config.Tags = append([]string{}, config.Tags...)
+		if len(groupTag) > 0 && groupTag[0] != "" && !findString(config.Tags, groupTag[0]) {
+			config.Tags = append(config.Tags, groupTag[0])
+		}
+
+		instance, err := c.CreateInstance(&config)
+		results[i] = InstanceCreateResult{Hostname: config.Hostname, Instance: instance, Err: err}
+	})
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.Hostname, result.Err))
+		}
+	}
+	if len(params) > 0 && len(errs) == len(params) {
+		return results, fmt.Errorf("all %d instances failed to create: %w", len(params), errors.Join(errs...))
+	}
+
+	return results, nil
+}
+
 // SetInstanceTags sets the tags for the specified instance
 func (c *Client) SetInstanceTags(i *Instance, tags string) (*SimpleResponse, error) {
 	resp, err := c.SendPutRequest(fmt.Sprintf("/v2/instances/%s/tags", i.ID), map[string]string{
@@ -260,6 +347,86 @@ func (c *Client) SetInstanceTags(i *Instance, tags string) (*SimpleResponse, err
 	return response, err
 }
 
+// TagMap returns the instance's tags as a map, splitting "key=value" tags at the first "=". Tags
+// without an "=" map to an empty value
+func (i *Instance) TagMap() map[string]string {
+	tags := make(map[string]string, len(i.Tags))
+	for _, tag := range i.Tags {
+		if tag == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(tag, "=")
+		tags[key] = value
+	}
+
+	return tags
+}
+
+// SSHTarget returns the address to SSH to the instance on: its private IP if preferPrivate is
+// true and it has one (e.g. when connecting through a bastion on the same network), otherwise its
+// public IP. An instance that has neither yet, e.g. one still building, returns an
+// InstanceNoIPError
+func (i *Instance) SSHTarget(preferPrivate bool) (string, error) {
+	if preferPrivate && i.PrivateIP != "" {
+		return i.PrivateIP, nil
+	}
+	if i.PublicIP != "" {
+		return i.PublicIP, nil
+	}
+	if i.PrivateIP != "" {
+		return i.PrivateIP, nil
+	}
+
+	err := fmt.Errorf("instance %s has no IP address assigned yet", i.ID)
+	return "", InstanceNoIPError.wrap(err)
+}
+
+// SetInstanceTag sets the "key=value" tag on the instance, replacing any tag it already has for
+// key, and saves the instance's tags. An empty value sets a plain "key" tag
+func (c *Client) SetInstanceTag(i *Instance, key, value string) (*SimpleResponse, error) {
+	if key == "" || strings.ContainsAny(key, "= \t\n") || strings.ContainsAny(value, " \t\n") {
+		err := fmt.Errorf("invalid tag %q=%q, keys can't be empty or contain \"=\" and neither can contain whitespace", key, value)
+		return nil, InstanceTagInvalidError.wrap(err)
+	}
+
+	tag := key
+	if value != "" {
+		tag = key + "=" + value
+	}
+
+	return c.saveInstanceTags(i, append(instanceTagsWithout(i.Tags, key), tag))
+}
+
+// RemoveInstanceTag removes the tag for key from the instance, whether or not it has a value,
+// and saves the instance's tags
+func (c *Client) RemoveInstanceTag(i *Instance, key string) (*SimpleResponse, error) {
+	return c.saveInstanceTags(i, instanceTagsWithout(i.Tags, key))
+}
+
+// instanceTagsWithout returns tags without any "key" or "key=..." entries
+func instanceTagsWithout(tags []string, key string) []string {
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if k, _, _ := strings.Cut(tag, "="); k == key || tag == "" {
+			continue
+		}
+		result = append(result, tag)
+	}
+
+	return result
+}
+
+// saveInstanceTags sends tags to the API and updates i.Tags once they are saved
+func (c *Client) saveInstanceTags(i *Instance, tags []string) (*SimpleResponse, error) {
+	response, err := c.SetInstanceTags(i, strings.Join(tags, " "))
+	if err != nil {
+		return nil, err
+	}
+
+	i.Tags = tags
+	return response, nil
+}
+
 // UpdateInstance updates an Instance's hostname, reverse DNS or notes
 func (c *Client) UpdateInstance(i *Instance) (*SimpleResponse, error) {
 	params := map[string]interface{}{
@@ -316,6 +483,53 @@ func (c *Client) DeleteInstance(id string) (*SimpleResponse, error) {
 	return response, err
 }
 
+// DeleteInstanceWithSnapshot takes a snapshot named snapshotName of the instance's root volume,
+// waits up to timeout for it to be ready and only then deletes the instance, returning the
+// snapshot. If the instance has no root volume, or the snapshot can't be taken, the instance isn't
+// deleted and the error says so. The snapshot is kept after the instance is gone. pollInterval
+// optionally overrides the default of 5 seconds between checks
+func (c *Client) DeleteInstanceWithSnapshot(id, snapshotName string, timeout time.Duration, pollInterval ...time.Duration) (*VolumeSnapshot, error) {
+	return c.DeleteInstanceWithSnapshotWithContext(context.Background(), id, snapshotName, timeout, pollInterval...)
+}
+
+// DeleteInstanceWithSnapshotWithContext is DeleteInstanceWithSnapshot bound to ctx
+func (c *Client) DeleteInstanceWithSnapshotWithContext(ctx context.Context, id, snapshotName string, timeout time.Duration, pollInterval ...time.Duration) (*VolumeSnapshot, error) {
+	instance, err := c.GetInstanceWithContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes, err := c.ListVolumes()
+	if err != nil {
+		return nil, err
+	}
+
+	var rootVolume *Volume
+	for i, volume := range volumes {
+		if volume.InstanceID == instance.ID && volume.Bootable {
+			rootVolume = &volumes[i]
+			break
+		}
+	}
+	if rootVolume == nil {
+		err := fmt.Errorf("instance %s has no root volume to snapshot, so it wasn't deleted", instance.ID)
+		return nil, InstanceNoRootVolumeError.wrap(err)
+	}
+
+	snapshot, err := c.SnapshotVolume(rootVolume.ID, snapshotName)
+	if err == nil {
+		snapshot, err = c.waitForVolumeSnapshotReady(ctx, rootVolume.ID, snapshot.SnapshotID, timeout, pollIntervalOrDefault(pollInterval))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("snapshot of instance %s failed, so it wasn't deleted: %w", instance.ID, err)
+	}
+
+	if _, err := c.DeleteInstance(instance.ID); err != nil {
+		return snapshot, err
+	}
+	return snapshot, nil
+}
+
 // RebootInstance reboots an instance (short version of HardRebootInstance)
 func (c *Client) RebootInstance(id string) (*SimpleResponse, error) {
 	return c.HardRebootInstance(id)
@@ -347,6 +561,39 @@ func (c *Client) SoftRebootInstance(id string) (*SimpleResponse, error) {
 	return response, err
 }
 
+// RebootInstanceWithOptions reboots an instance, either with a hard reboot (a power-cycle, see
+// HardRebootInstance) or a soft one (asking the OS to restart, see SoftRebootInstance). If wait is
+// true it then polls, for up to timeout, until the instance is back to ACTIVE; the first check is
+// one poll interval after the reboot, so it isn't mistaken for still running. It returns the
+// instance as it was last seen. pollInterval optionally overrides the default of 5 seconds
+// between checks
+func (c *Client) RebootInstanceWithOptions(id string, hard bool, wait bool, timeout time.Duration, pollInterval ...time.Duration) (*Instance, error) {
+	return c.RebootInstanceWithOptionsContext(context.Background(), id, hard, wait, timeout, pollInterval...)
+}
+
+// RebootInstanceWithOptionsContext is RebootInstanceWithOptions bound to ctx
+func (c *Client) RebootInstanceWithOptionsContext(ctx context.Context, id string, hard bool, wait bool, timeout time.Duration, pollInterval ...time.Duration) (*Instance, error) {
+	reboot := c.SoftRebootInstance
+	if hard {
+		reboot = c.HardRebootInstance
+	}
+	if _, err := reboot(id); err != nil {
+		return nil, err
+	}
+
+	if !wait {
+		return c.GetInstanceWithContext(ctx, id)
+	}
+
+	interval := pollIntervalOrDefault(pollInterval)
+	select {
+	case <-ctx.Done():
+		return nil, RequestCanceledError.wrap(ctx.Err())
+	case <-time.After(interval):
+	}
+	return c.WaitForInstanceStatusWithContext(ctx, id, InstanceStatusActive, timeout, interval)
+}
+
 // StopInstance shuts the power down to the instance
 func (c *Client) StopInstance(id string) (*SimpleResponse, error) {
 	resp, err := c.SendPutRequest(fmt.Sprintf("/v2/instances/%s/stop", id), map[string]string{
@@ -373,7 +620,58 @@ func (c *Client) StartInstance(id string) (*SimpleResponse, error) {
 	return response, err
 }
 
-// GetInstanceConsoleURL gets the web URL for an instance's console
+// StartInstanceWithOptions starts a stopped instance, first checking it isn't already running:
+// an instance that's already ACTIVE is returned along with an InstanceAlreadyInStateError, so
+// power-management scripts can treat it as done. If wait is true it then polls, for up to
+// timeout, until the instance is ACTIVE. pollInterval optionally overrides the default of 5
+// seconds between checks
+func (c *Client) StartInstanceWithOptions(id string, wait bool, timeout time.Duration, pollInterval ...time.Duration) (*Instance, error) {
+	return c.StartInstanceWithOptionsContext(context.Background(), id, wait, timeout, pollInterval...)
+}
+
+// StartInstanceWithOptionsContext is StartInstanceWithOptions bound to ctx
+func (c *Client) StartInstanceWithOptionsContext(ctx context.Context, id string, wait bool, timeout time.Duration, pollInterval ...time.Duration) (*Instance, error) {
+	return c.changeInstancePowerState(ctx, id, InstanceStatusActive, c.StartInstance, wait, timeout, pollInterval)
+}
+
+// StopInstanceWithOptions stops a running instance, first checking it isn't already stopped:
+// an instance that's already SHUTOFF is returned along with an InstanceAlreadyInStateError. If
+// wait is true it then polls, for up to timeout, until the instance is SHUTOFF. pollInterval
+// optionally overrides the default of 5 seconds between checks
+func (c *Client) StopInstanceWithOptions(id string, wait bool, timeout time.Duration, pollInterval ...time.Duration) (*Instance, error) {
+	return c.StopInstanceWithOptionsContext(context.Background(), id, wait, timeout, pollInterval...)
+}
+
+// StopInstanceWithOptionsContext is StopInstanceWithOptions bound to ctx
+func (c *Client) StopInstanceWithOptionsContext(ctx context.Context, id string, wait bool, timeout time.Duration, pollInterval ...time.Duration) (*Instance, error) {
+	return c.changeInstancePowerState(ctx, id, InstanceStatusShutoff, c.StopInstance, wait, timeout, pollInterval)
+}
+
+// changeInstancePowerState calls change to move the instance to status, unless it's there already
+func (c *Client) changeInstancePowerState(ctx context.Context, id, status string, change func(id string) (*SimpleResponse, error), wait bool, timeout time.Duration, pollInterval []time.Duration) (*Instance, error) {
+	instance, err := c.GetInstanceWithContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if instance.Status == status {
+		err := fmt.Errorf("instance %s is already %s", id, status)
+		return instance, InstanceAlreadyInStateError.wrap(err)
+	}
+
+	if _, err := change(id); err != nil {
+		return nil, err
+	}
+
+	if !wait {
+		return c.GetInstanceWithContext(ctx, id)
+	}
+	return c.WaitForInstanceStatusWithContext(ctx, id, status, timeout, pollInterval...)
+}
+
+// GetInstanceConsoleURL gets the web URL for an instance's console. The URL carries a one-off
+// token, and the API doesn't say when it expires, so fetch a new one each time rather than
+// storing it. When the expiry matters, use GetInstanceVnc instead: access then lasts for the
+// duration passed to it
 func (c *Client) GetInstanceConsoleURL(id string) (string, error) {
 	resp, err := c.SendGetRequest(fmt.Sprintf("/v2/instances/%s/console", id))
 	if err != nil {
@@ -381,8 +679,16 @@ func (c *Client) GetInstanceConsoleURL(id string) (string, error) {
 	}
 
 	console := InstanceConsole{}
-	err = json.NewDecoder(bytes.NewReader(resp)).Decode(&console)
-	return console.URL, err
+	if err := json.NewDecoder(bytes.NewReader(resp)).Decode(&console); err != nil {
+		return "", err
+	}
+
+	if console.URL == "" {
+		err := fmt.Errorf("no console URL was returned for instance %s", id)
+		return "", CannotGetConsoleError.wrap(err)
+	}
+
+	return console.URL, nil
 }
 
 // UpgradeInstance resizes the instance up to the new specification
@@ -400,6 +706,67 @@ func (c *Client) UpgradeInstance(id, newSize string) (*SimpleResponse, error) {
 	return response, err
 }
 
+// InstanceResizeResult is the result of ResizeInstance
+type InstanceResizeResult struct {
+	SimpleResponse
+	// RebootRequired is true when the new size changes the CPU or RAM, which can only be applied by rebooting
+	RebootRequired bool
+}
+
+// ResizeInstance resizes an ACTIVE instance to newSize, after checking newSize exists and
+// wouldn't shrink the instance's disk. If the instance's current size isn't listed the disks
+// can't be compared, so a ZeroMatchesError is returned rather than risking a downgrade
+func (c *Client) ResizeInstance(id, newSize string) (*InstanceResizeResult, error) {
+	instance, err := c.GetInstance(id)
+	if err != nil {
+		return nil, decodeError(err)
+	}
+
+	if instance.Status != InstanceStatusActive {
+		err := fmt.Errorf("instance %s is %s, it must be ACTIVE to be resized", id, instance.Status)
+		return nil, InstanceNotActiveError.wrap(err)
+	}
+
+	sizes, err := c.ListInstanceSizes()
+	if err != nil {
+		return nil, decodeError(err)
+	}
+
+	var currentSize, targetSize *InstanceSize
+	for k, size := range sizes {
+		if size.Name == instance.Size {
+			currentSize = &sizes[k]
+		}
+		if size.Name == newSize {
+			targetSize = &sizes[k]
+		}
+	}
+
+	if targetSize == nil {
+		err := fmt.Errorf("unable to find size %s, zero matches", newSize)
+		return nil, ZeroMatchesError.wrap(err)
+	}
+
+	// without the current size there's no telling whether the disk would shrink
+	if currentSize == nil {
+		err := fmt.Errorf("unable to find instance %s's current size %s, zero matches", id, instance.Size)
+		return nil, ZeroMatchesError.wrap(err)
+	}
+
+	if targetSize.DiskGigabytes < currentSize.DiskGigabytes {
+		err := fmt.Errorf("size %s has a %dGB disk, smaller than the %dGB of %s", newSize, targetSize.DiskGigabytes, currentSize.DiskGigabytes, instance.Size)
+		return nil, InstanceSizeDowngradeError.wrap(err)
+	}
+
+	response, err := c.UpgradeInstance(id, newSize)
+	if err != nil {
+		return nil, err
+	}
+
+	rebootRequired := targetSize.CPUCores != currentSize.CPUCores || targetSize.RAMMegabytes != currentSize.RAMMegabytes
+	return &InstanceResizeResult{SimpleResponse: *response, RebootRequired: rebootRequired}, nil
+}
+
 // MovePublicIPToInstance moves a public IP to the specified instance
 func (c *Client) MovePublicIPToInstance(id, ipAddress string) (*SimpleResponse, error) {
 	resp, err := c.SendPutRequest(fmt.Sprintf("/v2/instances/%s/ip/%s", id, ipAddress), "")
@@ -425,6 +792,22 @@ func (c *Client) SetInstanceFirewall(id, firewallID string) (*SimpleResponse, er
 	return response, err
 }
 
+// GetInstanceFirewall returns the firewall the instance is attached to, or an
+// InstanceNoFirewallError if it doesn't have one
+func (c *Client) GetInstanceFirewall(instanceID string) (*Firewall, error) {
+	instance, err := c.GetInstance(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if instance.FirewallID == "" {
+		err := fmt.Errorf("instance %s has no firewall", instanceID)
+		return nil, InstanceNoFirewallError.wrap(err)
+	}
+
+	return c.FindFirewall(instance.FirewallID)
+}
+
 // EnableRecoveryMode enables recovery mode for the specified instance
 func (c *Client) EnableRecoveryMode(id string) (*SimpleResponse, error) {
 	resp, err := c.SendPutRequest(fmt.Sprintf("/v2/instances/%s/recovery?region=%s", id, c.Region), nil)
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -71,3 +72,62 @@ func (c *Client) FindInstanceSizes(search string) (*InstanceSize, error) {
 		return nil, ZeroMatchesError.wrap(err)
 	}
 }
+
+// SizeFilter is the minimum spec a size must have to be returned by FindSize. Zero fields aren't
+// checked, and Type (e.g. "instance", "kubernetes" or "database") is matched ignoring case
+type SizeFilter struct {
+	Type      string
+	MinCPU    int
+	MinRAMMB  int
+	MinDiskGB int
+}
+
+// FindSize returns the selectable sizes that meet the filter, smallest first: sorted by CPU
+// cores, then RAM, then disk. The API doesn't give prices, so the smallest size that fits is
+// taken to be the cheapest
+func (c *Client) FindSize(opts SizeFilter) ([]InstanceSize, error) {
+	sizes, err := c.ListInstanceSizes()
+	if err != nil {
+		return nil, err
+	}
+
+	matching := make([]InstanceSize, 0)
+	for _, size := range sizes {
+		if !size.Selectable ||
+			(opts.Type != "" && !strings.EqualFold(size.Type, opts.Type)) ||
+			size.CPUCores < opts.MinCPU ||
+			size.RAMMegabytes < opts.MinRAMMB ||
+			size.DiskGigabytes < opts.MinDiskGB {
+			continue
+		}
+		matching = append(matching, size)
+	}
+
+	sort.SliceStable(matching, func(i, j int) bool {
+		a, b := matching[i], matching[j]
+		if a.CPUCores != b.CPUCores {
+			return a.CPUCores < b.CPUCores
+		}
+		if a.RAMMegabytes != b.RAMMegabytes {
+			return a.RAMMegabytes < b.RAMMegabytes
+		}
+		return a.DiskGigabytes < b.DiskGigabytes
+	})
+
+	return matching, nil
+}
+
+// GetSmallestSizeForSpec returns the smallest size FindSize finds for the filter, or a
+// ZeroMatchesError if no size is big enough
+func (c *Client) GetSmallestSizeForSpec(opts SizeFilter) (*InstanceSize, error) {
+	sizes, err := c.FindSize(opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(sizes) == 0 {
+		err := fmt.Errorf("unable to find a %s size with %d CPU cores, %dMB RAM and %dGB disk, zero matches", opts.Type, opts.MinCPU, opts.MinRAMMB, opts.MinDiskGB)
+		return nil, ZeroMatchesError.wrap(err)
+	}
+
+	return &sizes[0], nil
+}
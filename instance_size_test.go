@@ -1,6 +1,8 @@
 package civogo
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -100,3 +102,44 @@ func TestFindInstanceSizes(t *testing.T) {
 		t.Errorf("Expected %s, got %s", "g3.xsmall", got.Name)
 	}
 }
+
+func TestFindSize(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/sizes": `[
+			{"type": "Instance", "name": "g3.large", "cpu_cores": 4, "ram_mb": 8192, "disk_gb": 100, "selectable": true},
+			{"type": "Instance", "name": "g3.medium", "cpu_cores": 2, "ram_mb": 4096, "disk_gb": 50, "selectable": true},
+			{"type": "Instance", "name": "g3.medium.legacy", "cpu_cores": 2, "ram_mb": 4096, "disk_gb": 50, "selectable": false},
+			{"type": "Instance", "name": "g3.xsmall", "cpu_cores": 1, "ram_mb": 1024, "disk_gb": 25, "selectable": true},
+			{"type": "Kubernetes", "name": "g4s.kube.medium", "cpu_cores": 2, "ram_mb": 4096, "disk_gb": 50, "selectable": true},
+			{"type": "Instance", "name": "g3.memory", "cpu_cores": 2, "ram_mb": 16384, "disk_gb": 50, "selectable": true}
+		]`,
+	})
+	defer server.Close()
+
+	got, err := client.FindSize(SizeFilter{Type: "instance", MinCPU: 2, MinRAMMB: 4096})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	names := []string{}
+	for _, size := range got {
+		names = append(names, size.Name)
+	}
+	expected := "[g3.medium g3.memory g3.large]"
+	if fmt.Sprint(names) != expected {
+		t.Errorf("Expected %s, got %v", expected, names)
+	}
+
+	smallest, err := client.GetSmallestSizeForSpec(SizeFilter{Type: "kubernetes", MinRAMMB: 2048})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if smallest.Name != "g4s.kube.medium" {
+		t.Errorf("Expected %s, got %s", "g4s.kube.medium", smallest.Name)
+	}
+
+	if _, err := client.GetSmallestSizeForSpec(SizeFilter{MinCPU: 64}); !errors.Is(err, ZeroMatchesError) {
+		t.Errorf("Expected %v, got %v", ZeroMatchesError, err)
+	}
+}
@@ -1,7 +1,16 @@
 package civogo
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestListInstances(t *testing.T) {
@@ -50,6 +59,15 @@ func TestFindInstance(t *testing.T) {
 	if err.Error() != "MultipleMatchesError: unable to find com because there were multiple matches" {
 		t.Errorf("Expected %s, got %s", "unable to find com because there were multiple matches", err.Error())
 	}
+	var ambiguity *MatchAmbiguityError
+	if !errors.As(err, &ambiguity) {
+		t.Errorf("Expected a MatchAmbiguityError, got %v", err)
+	} else {
+		expected := []MatchCandidate{{ID: "12345", Name: "foo.example.com"}, {ID: "67890", Name: "bar.zip.com"}}
+		if !reflect.DeepEqual(ambiguity.Candidates, expected) {
+			t.Errorf("Expected %+v, got %+v", expected, ambiguity.Candidates)
+		}
+	}
 
 	_, err = client.FindInstance("missing")
 	if err.Error() != "ZeroMatchesError: unable to find missing, zero matches" {
@@ -57,6 +75,22 @@ func TestFindInstance(t *testing.T) {
 	}
 }
 
+func TestFindInstanceExactMatchWins(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/instances": `{"page": 1, "per_page": 20, "pages": 1, "items":[{"id": "12345", "hostname": "web-1"}, {"id": "23456", "hostname": "web-2"}, {"id": "34567", "hostname": "web"}]}`,
+	})
+	defer server.Close()
+
+	got, err := client.FindInstance("web")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.ID != "34567" {
+		t.Errorf("Expected %s, got %s", "34567", got.ID)
+	}
+}
+
 func TestListInstancesWithPage(t *testing.T) {
 	client, server, _ := NewClientForTesting(map[string]string{
 		"/v2/instances?page=2&per_page=10": `{"page": 1, "per_page": 20, "pages": 2, "items":[{"id": "12345", "hostname": "foo.example.com"}]}`,
@@ -216,6 +250,72 @@ func TestSetInstanceTags(t *testing.T) {
 	EnsureSuccessfulSimpleResponse(t, got, err)
 }
 
+func TestInstanceTagMap(t *testing.T) {
+	i := &Instance{Tags: []string{"env=prod", "team=web=ops", "lamp", ""}}
+
+	expected := map[string]string{"env": "prod", "team": "web=ops", "lamp": ""}
+	if got := i.TagMap(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestInstanceSSHTarget(t *testing.T) {
+	tests := []struct {
+		instance      Instance
+		preferPrivate bool
+		expected      string
+	}{
+		{Instance{PublicIP: "74.220.21.10", PrivateIP: "192.168.1.10"}, true, "192.168.1.10"},
+		{Instance{PublicIP: "74.220.21.10", PrivateIP: "192.168.1.10"}, false, "74.220.21.10"},
+		{Instance{PublicIP: "74.220.21.10"}, true, "74.220.21.10"},
+		{Instance{PrivateIP: "192.168.1.10"}, false, "192.168.1.10"},
+	}
+	for _, test := range tests {
+		got, err := test.instance.SSHTarget(test.preferPrivate)
+		if err != nil {
+			t.Errorf("SSHTarget returned an error: %s", err)
+			continue
+		}
+		if got != test.expected {
+			t.Errorf("Expected %s, got %s", test.expected, got)
+		}
+	}
+
+	i := &Instance{ID: "12345", Status: InstanceStatusBuilding}
+	if _, err := i.SSHTarget(true); !errors.Is(err, InstanceNoIPError) {
+		t.Errorf("Expected %v, got %v", InstanceNoIPError, err)
+	}
+}
+
+func TestSetAndRemoveInstanceTag(t *testing.T) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var body map[string]string
+		json.NewDecoder(req.Body).Decode(&body)
+		sent = append(sent, body["tags"])
+		rw.Write([]byte(`{"result": "success"}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	i := &Instance{ID: "12345", Tags: []string{"env=staging", "lamp"}}
+	got, err := client.SetInstanceTag(i, "env", "prod")
+	EnsureSuccessfulSimpleResponse(t, got, err)
+	got, err = client.RemoveInstanceTag(i, "lamp")
+	EnsureSuccessfulSimpleResponse(t, got, err)
+
+	if !reflect.DeepEqual(sent, []string{"lamp env=prod", "env=prod"}) {
+		t.Errorf("Expected the tags to be saved as %q then %q, got %q", "lamp env=prod", "env=prod", sent)
+	}
+	if !reflect.DeepEqual(i.Tags, []string{"env=prod"}) {
+		t.Errorf("Expected the instance to be tagged %v, got %v", []string{"env=prod"}, i.Tags)
+	}
+
+	if _, err := client.SetInstanceTag(i, "cost centre", "42"); !errors.Is(err, InstanceTagInvalidError) {
+		t.Errorf("Expected %v, got %v", InstanceTagInvalidError, err)
+	}
+}
+
 func TestUpdateInstance(t *testing.T) {
 	client, server, _ := NewAdvancedClientForTesting([]ConfigAdvanceClientForTesting{
 		{
@@ -260,6 +360,66 @@ func TestDeleteInstance(t *testing.T) {
 	EnsureSuccessfulSimpleResponse(t, got, err)
 }
 
+func TestDeleteInstanceWithSnapshot(t *testing.T) {
+	var mu sync.Mutex
+	snapshotState := "failed"
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case req.Method == "DELETE":
+			deleted = append(deleted, req.URL.Path)
+			rw.Write([]byte(`{"result": "success"}`))
+		case req.URL.Path == "/v2/instances/12345":
+			rw.Write([]byte(`{"id": "12345", "hostname": "foo.example.com", "status": "ACTIVE"}`))
+		case req.URL.Path == "/v2/instances/67890":
+			rw.Write([]byte(`{"id": "67890", "hostname": "bar.example.com", "status": "ACTIVE"}`))
+		case req.URL.Path == "/v2/volumes":
+			rw.Write([]byte(`[{"id": "vol-2", "instance_id": "12345", "bootable": false}, {"id": "vol-1", "instance_id": "12345", "bootable": true}]`))
+		case req.Method == "POST" && req.URL.Path == "/v2/volumes/vol-1/snapshots":
+			rw.Write([]byte(`{"name": "final", "snapshot_id": "snap-1", "volume_id": "vol-1", "state": "pending"}`))
+		case req.URL.Path == "/v2/volumes/vol-1/snapshots/snap-1":
+			rw.Write([]byte(`{"name": "final", "snapshot_id": "snap-1", "volume_id": "vol-1", "state": "` + snapshotState + `"}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	_, err := client.DeleteInstanceWithSnapshot("12345", "final", time.Second, time.Millisecond)
+	if !errors.Is(err, VolumeSnapshotFailedError) {
+		t.Errorf("Expected %v, got %v", VolumeSnapshotFailedError, err)
+	}
+
+	_, err = client.DeleteInstanceWithSnapshot("67890", "final", time.Second, time.Millisecond)
+	if !errors.Is(err, InstanceNoRootVolumeError) {
+		t.Errorf("Expected %v, got %v", InstanceNoRootVolumeError, err)
+	}
+
+	if len(deleted) != 0 {
+		t.Errorf("Expected no deletes, got %v", deleted)
+	}
+
+	mu.Lock()
+	snapshotState = "ready"
+	mu.Unlock()
+	got, err := client.DeleteInstanceWithSnapshot("12345", "final", time.Second, time.Millisecond)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.SnapshotID != "snap-1" || got.State != "ready" {
+		t.Errorf("Expected a ready snap-1, got %+v", got)
+	}
+
+	expected := []string{"/v2/instances/12345"}
+	if !reflect.DeepEqual(deleted, expected) {
+		t.Errorf("Expected %v, got %v", expected, deleted)
+	}
+}
+
 func TestRebootInstance(t *testing.T) {
 	client, server, _ := NewAdvancedClientForTesting([]ConfigAdvanceClientForTesting{
 		{
@@ -317,6 +477,127 @@ func TestSoftRebootInstance(t *testing.T) {
 	EnsureSuccessfulSimpleResponse(t, got, err)
 }
 
+func TestRebootInstanceWithOptions(t *testing.T) {
+	var reboots []string
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "POST" {
+			reboots = append(reboots, req.URL.Path)
+			rw.Write([]byte(`{"result": "success"}`))
+			return
+		}
+		gets++
+		if gets < 3 {
+			rw.Write([]byte(`{"id": "12345", "hostname": "foo.example.com", "status": "REBOOTING"}`))
+			return
+		}
+		rw.Write([]byte(`{"id": "12345", "hostname": "foo.example.com", "status": "ACTIVE"}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.RebootInstanceWithOptions("12345", true, true, time.Second, time.Millisecond)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.Status != "ACTIVE" {
+		t.Errorf("Expected %s, got %s", "ACTIVE", got.Status)
+	}
+	if gets != 3 {
+		t.Errorf("Expected the instance to be checked %d times, got %d", 3, gets)
+	}
+
+	got, err = client.RebootInstanceWithOptions("12345", false, false, time.Second, time.Millisecond)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.ID != "12345" {
+		t.Errorf("Expected %s, got %s", "12345", got.ID)
+	}
+
+	expected := []string{"/v2/instances/12345/hard_reboots", "/v2/instances/12345/soft_reboots"}
+	if !reflect.DeepEqual(reboots, expected) {
+		t.Errorf("Expected %v, got %v", expected, reboots)
+	}
+}
+
+func TestRebootInstanceWithOptionsContextCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "POST" {
+			rw.Write([]byte(`{"result": "success"}`))
+			return
+		}
+		rw.Write([]byte(`{"id": "12345", "hostname": "foo.example.com", "status": "REBOOTING"}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.RebootInstanceWithOptionsContext(ctx, "12345", true, true, time.Hour, time.Hour)
+	if !errors.Is(err, RequestCanceledError) {
+		t.Errorf("Expected %v, got %v", RequestCanceledError, err)
+	}
+}
+
+func TestStartStopInstanceWithOptions(t *testing.T) {
+	status := "SHUTOFF"
+	var actions []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v2/instances/12345/start":
+			actions = append(actions, "start")
+			status = "ACTIVE"
+			rw.Write([]byte(`{"result": "success"}`))
+		case "/v2/instances/12345/stop":
+			actions = append(actions, "stop")
+			status = "SHUTOFF"
+			rw.Write([]byte(`{"result": "success"}`))
+		default:
+			rw.Write([]byte(`{"id": "12345", "status": "` + status + `"}`))
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.StartInstanceWithOptions("12345", true, time.Second, time.Millisecond)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.Status != "ACTIVE" {
+		t.Errorf("Expected %s, got %s", "ACTIVE", got.Status)
+	}
+
+	got, err = client.StartInstanceWithOptions("12345", true, time.Second, time.Millisecond)
+	if !errors.Is(err, InstanceAlreadyInStateError) {
+		t.Errorf("Expected %v, got %v", InstanceAlreadyInStateError, err)
+	}
+	if got == nil || got.Status != "ACTIVE" {
+		t.Errorf("Expected the ACTIVE instance, got %+v", got)
+	}
+
+	got, err = client.StopInstanceWithOptions("12345", false, time.Second, time.Millisecond)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.Status != "SHUTOFF" {
+		t.Errorf("Expected %s, got %s", "SHUTOFF", got.Status)
+	}
+
+	if _, err := client.StopInstanceWithOptions("12345", false, time.Second, time.Millisecond); !errors.Is(err, InstanceAlreadyInStateError) {
+		t.Errorf("Expected %v, got %v", InstanceAlreadyInStateError, err)
+	}
+
+	expected := []string{"start", "stop"}
+	if !reflect.DeepEqual(actions, expected) {
+		t.Errorf("Expected %v, got %v", expected, actions)
+	}
+}
+
 func TestStopInstance(t *testing.T) {
 	client, server, _ := NewAdvancedClientForTesting([]ConfigAdvanceClientForTesting{
 		{
@@ -415,6 +696,110 @@ func TestGetInstanceConsoleURL(t *testing.T) {
 	}
 }
 
+func TestGetInstanceConsoleURLEmpty(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/instances/12345/console": `{"url": ""}`,
+	})
+	defer server.Close()
+
+	_, err := client.GetInstanceConsoleURL("12345")
+	if !errors.Is(err, CannotGetConsoleError) {
+		t.Errorf("Expected %v, got %v", CannotGetConsoleError, err)
+	}
+}
+
+func TestCreateInstanceScript(t *testing.T) {
+	var sent string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var config InstanceConfig
+		json.NewDecoder(req.Body).Decode(&config)
+		sent = config.Script
+		rw.Write([]byte(`{"id": "12345"}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	config := &InstanceConfig{Hostname: "foo", Script: "#!/bin/bash\necho hi", EncodeScript: true}
+	if _, err := client.CreateInstance(config); err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if sent != "IyEvYmluL2Jhc2gKZWNobyBoaQ==" {
+		t.Errorf("Expected %s, got %s", "IyEvYmluL2Jhc2gKZWNobyBoaQ==", sent)
+	}
+	if config.Script != "#!/bin/bash\necho hi" {
+		t.Errorf("Expected the config's script to be left alone, got %s", config.Script)
+	}
+
+	sent = ""
+	_, err := client.CreateInstance(&InstanceConfig{Hostname: "foo", Script: strings.Repeat("a", MaxInstanceScriptSize+1)})
+	if !errors.Is(err, InstanceScriptTooLargeError) {
+		t.Errorf("Expected %v, got %v", InstanceScriptTooLargeError, err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "16385 bytes") {
+		t.Errorf("Expected the error to give the script's size, got %s", err)
+	}
+
+	// encoding makes the script a third bigger, which is what's checked
+	_, err = client.CreateInstance(&InstanceConfig{Hostname: "foo", Script: strings.Repeat("a", 13*1024), EncodeScript: true})
+	if !errors.Is(err, InstanceScriptTooLargeError) {
+		t.Errorf("Expected %v, got %v", InstanceScriptTooLargeError, err)
+	}
+	if sent != "" {
+		t.Errorf("Expected nothing to be sent, got %s", sent)
+	}
+}
+
+func TestCreateInstances(t *testing.T) {
+	var mu sync.Mutex
+	tags := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var config InstanceConfig
+		json.NewDecoder(req.Body).Decode(&config)
+		if config.Hostname == "worker-2" {
+			rw.WriteHeader(http.StatusBadRequest)
+			rw.Write([]byte(`{"code":"database_instance_build","reason":"invalid hostname"}`))
+			return
+		}
+		mu.Lock()
+		tags[config.Hostname] = config.TagsList
+		mu.Unlock()
+		rw.Write([]byte(`{"id": "id-` + config.Hostname + `", "hostname": "` + config.Hostname + `"}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	params := []InstanceConfig{
+		{Hostname: "worker-1", Tags: []string{"web"}},
+		{Hostname: "worker-2"},
+		{Hostname: "worker-3", Tags: []string{"pool-a"}},
+	}
+	got, err := client.CreateInstances(params, "pool-a")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+
+	if len(got) != 3 || got[0].Instance.ID != "id-worker-1" || got[2].Instance.ID != "id-worker-3" {
+		t.Errorf("Expected worker-1 and worker-3 to be created in order, got %+v", got)
+	}
+	if got[1].Err == nil || got[1].Instance != nil {
+		t.Errorf("Expected worker-2 to fail, got %+v", got[1])
+	}
+	expected := map[string]string{"worker-1": "web pool-a", "worker-3": "pool-a"}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("Expected %v, got %v", expected, tags)
+	}
+	if len(params[0].Tags) != 1 {
+		t.Errorf("Expected the configs passed in to be left alone, got %v", params[0].Tags)
+	}
+
+	_, err = client.CreateInstances([]InstanceConfig{{Hostname: "worker-2"}, {Hostname: "worker-2"}})
+	if err == nil || !strings.Contains(err.Error(), "all 2 instances failed") {
+		t.Errorf("Expected all the instances to fail, got %v", err)
+	}
+}
+
 func TestSetInstanceFirewall(t *testing.T) {
 	client, server, _ := NewAdvancedClientForTesting([]ConfigAdvanceClientForTesting{
 		{
@@ -434,6 +819,29 @@ func TestSetInstanceFirewall(t *testing.T) {
 	EnsureSuccessfulSimpleResponse(t, got, err)
 }
 
+func TestGetInstanceFirewall(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/instances/12345": `{"id": "12345", "hostname": "foo.example.com", "firewall_id": "67890"}`,
+		"/v2/instances/54321": `{"id": "54321", "hostname": "bar.example.com"}`,
+		"/v2/firewalls":       `[{"id": "67890", "name": "web"}, {"id": "678901", "name": "web-2"}]`,
+	})
+	defer server.Close()
+
+	got, err := client.GetInstanceFirewall("12345")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.ID != "67890" || got.Name != "web" {
+		t.Errorf("Expected firewall %s, got %+v", "67890", got)
+	}
+
+	_, err = client.GetInstanceFirewall("54321")
+	if !errors.Is(err, InstanceNoFirewallError) {
+		t.Errorf("Expected %v, got %v", InstanceNoFirewallError, err)
+	}
+}
+
 func TestEnableRecoveryMode(t *testing.T) {
 	client, server, _ := NewClientForTesting(map[string]string{
 		"/v2/instances/12345/recovery": `{"result": "success"}`,
@@ -463,3 +871,130 @@ func TestGetRecoveryStatus(t *testing.T) {
 	got, err := client.GetRecoveryStatus("12345")
 	EnsureSuccessfulSimpleResponse(t, got, err)
 }
+
+func newResizeTestServer(status, size string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/v2/instances/12345":
+			rw.Write([]byte(`{"id":"12345","status":"` + status + `","size":"` + size + `"}`))
+		case req.Method == "GET" && req.URL.Path == "/v2/sizes":
+			rw.Write([]byte(`[{"name":"g3.small","cpu_cores":1,"ram_mb":2048,"disk_gb":25},{"name":"g3.medium","cpu_cores":2,"ram_mb":4096,"disk_gb":50},{"name":"g3.medium.disk","cpu_cores":2,"ram_mb":4096,"disk_gb":100}]`))
+		case req.Method == "PUT" && req.URL.Path == "/v2/instances/12345/resize":
+			rw.Write([]byte(`{"result":"success"}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			rw.Write([]byte(`{"code":"unexpected_request"}`))
+		}
+	}))
+}
+
+func TestResizeInstance(t *testing.T) {
+	server := newResizeTestServer("ACTIVE", "g3.small")
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.ResizeInstance("12345", "g3.medium")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.Result != "success" || !got.RebootRequired {
+		t.Errorf("Expected a successful resize requiring a reboot, got %+v", got)
+	}
+
+	server = newResizeTestServer("ACTIVE", "g3.medium")
+	defer server.Close()
+	client, _ = NewClientForTestingWithServer(server)
+
+	got, err = client.ResizeInstance("12345", "g3.medium.disk")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.RebootRequired {
+		t.Errorf("Expected a disk only resize not to require a reboot, got %+v", got)
+	}
+}
+
+func TestResizeInstanceValidation(t *testing.T) {
+	server := newResizeTestServer("ACTIVE", "g3.medium")
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	if _, err := client.ResizeInstance("12345", "g3.small"); !errors.Is(err, InstanceSizeDowngradeError) {
+		t.Errorf("Expected %v, got %v", InstanceSizeDowngradeError, err)
+	}
+	if _, err := client.ResizeInstance("12345", "g99.huge"); !errors.Is(err, ZeroMatchesError) {
+		t.Errorf("Expected %v, got %v", ZeroMatchesError, err)
+	}
+
+	server = newResizeTestServer("SHUTOFF", "g3.small")
+	defer server.Close()
+	client, _ = NewClientForTestingWithServer(server)
+
+	if _, err := client.ResizeInstance("12345", "g3.medium"); !errors.Is(err, InstanceNotActiveError) {
+		t.Errorf("Expected %v, got %v", InstanceNotActiveError, err)
+	}
+
+	// an instance on a size that isn't listed can't be checked for a disk downgrade
+	server = newResizeTestServer("ACTIVE", "g2.retired")
+	defer server.Close()
+	client, _ = NewClientForTestingWithServer(server)
+
+	if _, err := client.ResizeInstance("12345", "g3.small"); !errors.Is(err, ZeroMatchesError) {
+		t.Errorf("Expected %v, got %v", ZeroMatchesError, err)
+	}
+}
+
+func TestWaitForInstanceStatus(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+		status := "BUILDING"
+		if calls > 2 {
+			status = "ACTIVE"
+		}
+		rw.Write([]byte(`{"id":"12345","hostname":"foo.example.com","status":"` + status + `"}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.WaitForInstanceStatus("12345", "ACTIVE", time.Second, time.Millisecond)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.Status != "ACTIVE" || got.Hostname != "foo.example.com" {
+		t.Errorf("Expected an ACTIVE foo.example.com, got %+v", got)
+	}
+	if calls != 3 {
+		t.Errorf("Expected %d, got %d", 3, calls)
+	}
+}
+
+func TestWaitForInstanceStatusFailed(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/instances/12345": `{"id":"12345","status":"ERROR"}`,
+	})
+	defer server.Close()
+
+	got, err := client.WaitForInstanceStatus("12345", "ACTIVE", time.Second, time.Millisecond)
+	if !errors.Is(err, InstanceFailedError) {
+		t.Errorf("Expected %v, got %v", InstanceFailedError, err)
+	}
+	if got == nil || got.Status != "ERROR" {
+		t.Errorf("Expected the failed instance to be returned, got %+v", got)
+	}
+}
+
+func TestWaitForInstanceStatusTimeout(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/instances/12345": `{"id":"12345","status":"BUILDING"}`,
+	})
+	defer server.Close()
+
+	_, err := client.WaitForInstanceStatus("12345", "ACTIVE", 20*time.Millisecond, time.Millisecond)
+	if !errors.Is(err, TimeoutError) {
+		t.Errorf("Expected %v, got %v", TimeoutError, err)
+	}
+}
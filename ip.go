@@ -61,7 +61,12 @@ type Actions struct {
 
 // ListIPs returns all reserved IPs in that specific region
 func (c *Client) ListIPs() (*PaginatedIPs, error) {
-	resp, err := c.SendGetRequest("/v2/ips")
+	return c.listIPs("/v2/ips")
+}
+
+// listIPs fetches the reserved IPs at url, which may ask for a particular page
+func (c *Client) listIPs(url string) (*PaginatedIPs, error) {
+	resp, err := c.SendGetRequest(url)
 	if err != nil {
 		return nil, decodeError(err)
 	}
@@ -191,6 +196,113 @@ func (c *Client) UnassignIP(id, region string) (*SimpleResponse, error) {
 	return c.DecodeSimpleResponse(resp)
 }
 
+// AssignReservedIP assigns a reserved IP to an instance in the client's region. Assigning an IP to
+// the instance it is already assigned to does nothing
+func (c *Client) AssignReservedIP(ipID, instanceID string) error {
+	if len(ipID) == 0 || len(instanceID) == 0 {
+		err := fmt.Errorf("the reserved IP ID and instance ID are required")
+		return IDisEmptyError.wrap(err)
+	}
+
+	ip, err := c.GetIP(ipID)
+	if err != nil {
+		return err
+	}
+
+	if ip.AssignedTo.Type == "instance" && ip.AssignedTo.ID == instanceID {
+		return nil
+	}
+
+	_, err = c.AssignIP(ipID, instanceID, "instance", c.Region)
+	return err
+}
+
+// UnassignReservedIP unassigns a reserved IP from whatever it is assigned to in the client's region
+func (c *Client) UnassignReservedIP(ipID string) error {
+	if len(ipID) == 0 {
+		err := fmt.Errorf("the reserved IP ID is empty")
+		return IDisEmptyError.wrap(err)
+	}
+
+	_, err := c.UnassignIP(ipID, c.Region)
+	return err
+}
+
+// MoveReservedIP moves a reserved IP from one instance to another, first checking it is assigned to
+// fromInstanceID so a stale caller can't take the IP from some other instance. Moving an IP that is
+// already on toInstanceID does nothing
+func (c *Client) MoveReservedIP(ipID, fromInstanceID, toInstanceID string) error {
+	if len(ipID) == 0 || len(fromInstanceID) == 0 || len(toInstanceID) == 0 {
+		err := fmt.Errorf("the reserved IP ID and both instance IDs are required")
+		return IDisEmptyError.wrap(err)
+	}
+
+	ip, err := c.GetIP(ipID)
+	if err != nil {
+		return err
+	}
+
+	if ip.AssignedTo.Type == "instance" && ip.AssignedTo.ID == toInstanceID {
+		return nil
+	}
+
+	if ip.AssignedTo.Type != "instance" || ip.AssignedTo.ID != fromInstanceID {
+		err := fmt.Errorf("reserved IP %s is not assigned to instance %s", ipID, fromInstanceID)
+		return ReservedIPNotAssignedToInstanceError.wrap(err)
+	}
+
+	_, err = c.AssignIP(ipID, toInstanceID, "instance", c.Region)
+	return err
+}
+
+// ReservedIPAssignment pairs a reserved IP with the instance it's assigned to
+type ReservedIPAssignment struct {
+	IP IP
+	// AssignedTo is the ID of the instance the IP is assigned to, empty if it isn't assigned to
+	// one (IP.AssignedTo still says if it's on a load balancer)
+	AssignedTo string
+	// Hostname is the hostname of that instance, empty if it couldn't be found
+	Hostname string
+}
+
+// ListReservedIPsWithAssignments returns the reserved IPs in the client's region along with the
+// ID and hostname of the instance each one is assigned to, e.g. for IP inventory audits. The
+// hostnames are looked up with a single listing of the instances, and every page of IPs is fetched
+func (c *Client) ListReservedIPsWithAssignments() ([]ReservedIPAssignment, error) {
+	assignments := []ReservedIPAssignment{}
+	var hostnames map[string]string
+	err := eachItem(func(page int) ([]IP, int, error) {
+		ips, err := c.listIPs(fmt.Sprintf("/v2/ips?page=%d&per_page=%d", page, eachPageSize))
+		if err != nil {
+			return nil, 0, err
+		}
+		return ips.Items, ips.Pages, nil
+	}, func(ip IP) error {
+		assignment := ReservedIPAssignment{IP: ip}
+		if ip.AssignedTo.Type == "instance" && ip.AssignedTo.ID != "" {
+			if hostnames == nil {
+				instances, err := c.ListAllInstances()
+				if err != nil {
+					return err
+				}
+				hostnames = make(map[string]string, len(instances))
+				for _, instance := range instances {
+					hostnames[instance.ID] = instance.Hostname
+				}
+			}
+			assignment.AssignedTo = ip.AssignedTo.ID
+			assignment.Hostname = hostnames[ip.AssignedTo.ID]
+		}
+		assignments = append(assignments, assignment)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
 // DeleteIP deletes an IP
 func (c *Client) DeleteIP(id string) (*SimpleResponse, error) {
 	resp, err := c.SendDeleteRequest(fmt.Sprintf("/v2/ips/%s", id))
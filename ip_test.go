@@ -1,6 +1,10 @@
 package civogo
 
 import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 )
@@ -187,3 +191,141 @@ func TestUnAssignIP(t *testing.T) {
 		t.Errorf("Expected %+v, got %+v", expected, got)
 	}
 }
+
+func newReservedIPTestServer(assignedTo string, actions *[]Actions) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "POST" {
+			var action Actions
+			json.NewDecoder(req.Body).Decode(&action)
+			*actions = append(*actions, action)
+			rw.Write([]byte(`{"result": "success"}`))
+			return
+		}
+		rw.Write([]byte(`{"id": "12345", "ip": "192.168.1.1", "assigned_to": {"id": "` + assignedTo + `", "type": "instance"}}`))
+	}))
+}
+
+func TestAssignReservedIP(t *testing.T) {
+	var actions []Actions
+	server := newReservedIPTestServer("instance-1", &actions)
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	if err := client.AssignReservedIP("12345", "instance-1"); err != nil || len(actions) != 0 {
+		t.Errorf("Expected assigning to the same instance to do nothing, got %v and %+v", err, actions)
+	}
+
+	if err := client.AssignReservedIP("12345", "instance-2"); err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(actions) != 1 || actions[0].Action != "assign" || actions[0].AssignToID != "instance-2" || actions[0].AssignToType != "instance" {
+		t.Errorf("Expected an assign to instance-2, got %+v", actions)
+	}
+}
+
+func TestMoveReservedIP(t *testing.T) {
+	var actions []Actions
+	server := newReservedIPTestServer("instance-1", &actions)
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	if err := client.MoveReservedIP("12345", "instance-3", "instance-2"); !errors.Is(err, ReservedIPNotAssignedToInstanceError) {
+		t.Errorf("Expected %v, got %v", ReservedIPNotAssignedToInstanceError, err)
+	}
+
+	if err := client.MoveReservedIP("12345", "instance-2", "instance-1"); err != nil || len(actions) != 0 {
+		t.Errorf("Expected moving to the current instance to do nothing, got %v and %+v", err, actions)
+	}
+
+	if err := client.MoveReservedIP("12345", "instance-1", "instance-2"); err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(actions) != 1 || actions[0].AssignToID != "instance-2" {
+		t.Errorf("Expected an assign to instance-2, got %+v", actions)
+	}
+}
+
+func TestUnassignReservedIP(t *testing.T) {
+	var actions []Actions
+	server := newReservedIPTestServer("instance-1", &actions)
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	if err := client.UnassignReservedIP("12345"); err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(actions) != 1 || actions[0].Action != "unassign" || actions[0].Region != "TEST" {
+		t.Errorf("Expected an unassign in TEST, got %+v", actions)
+	}
+}
+
+func TestListReservedIPsWithAssignments(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/ips": `{"page": 1, "per_page": 20, "pages": 1, "items": [
+			{"id": "ip-1", "name": "web", "ip": "192.168.1.1", "assigned_to": {"id": "12345", "type": "instance", "name": "foo"}},
+			{"id": "ip-2", "name": "lb", "ip": "192.168.1.2", "assigned_to": {"id": "lb-1", "type": "loadbalancer", "name": "bar"}},
+			{"id": "ip-3", "name": "spare", "ip": "192.168.1.3"}
+		]}`,
+		"/v2/instances": `{"page": 1, "per_page": 20, "pages": 1, "items": [{"id": "12345", "hostname": "foo.example.com"}]}`,
+	})
+	defer server.Close()
+
+	got, err := client.ListReservedIPsWithAssignments()
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+
+	expected := []struct{ id, assignedTo, hostname string }{
+		{"ip-1", "12345", "foo.example.com"},
+		{"ip-2", "", ""},
+		{"ip-3", "", ""},
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %d IPs, got %d", len(expected), len(got))
+	}
+	for i, e := range expected {
+		if got[i].IP.ID != e.id || got[i].AssignedTo != e.assignedTo || got[i].Hostname != e.hostname {
+			t.Errorf("Expected %+v, got %+v", e, got[i])
+		}
+	}
+}
+
+func TestListReservedIPsWithAssignmentsPaginated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Path == "/v2/ips" && req.URL.Query().Get("page") == "1":
+			rw.Write([]byte(`{"page": 1, "per_page": 1, "pages": 2, "items": [{"id": "ip-1", "ip": "192.168.1.1", "assigned_to": {"id": "12345", "type": "instance"}}]}`))
+		case req.URL.Path == "/v2/ips" && req.URL.Query().Get("page") == "2":
+			rw.Write([]byte(`{"page": 2, "per_page": 1, "pages": 2, "items": [{"id": "ip-2", "ip": "192.168.1.2", "assigned_to": {"id": "67890", "type": "instance"}}]}`))
+		case req.URL.Path == "/v2/instances":
+			rw.Write([]byte(`{"page": 1, "per_page": 20, "pages": 1, "items": [{"id": "12345", "hostname": "foo.example.com"}, {"id": "67890", "hostname": "bar.example.com"}]}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.ListReservedIPsWithAssignments()
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+
+	expected := []struct{ id, assignedTo, hostname string }{
+		{"ip-1", "12345", "foo.example.com"},
+		{"ip-2", "67890", "bar.example.com"},
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %d IPs, got %d", len(expected), len(got))
+	}
+	for i, e := range expected {
+		if got[i].IP.ID != e.id || got[i].AssignedTo != e.assignedTo || got[i].Hostname != e.hostname {
+			t.Errorf("Expected %+v, got %+v", e, got[i])
+		}
+	}
+}
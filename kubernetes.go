@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"golang.org/x/mod/semver"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -110,6 +112,9 @@ type KubernetesCluster struct {
 	Conditions            []Condition                      `json:"conditions"`
 }
 
+// KubernetesClusterStatusActive is the Status of a cluster that's running and ready to use
+const KubernetesClusterStatusActive = "ACTIVE"
+
 // RequiredPools returns the required pools for a given Kubernetes cluster
 type RequiredPools struct {
 	ID               string            `json:"id"`
@@ -275,6 +280,31 @@ func (c *Client) GetKubernetesCluster(id string) (*KubernetesCluster, error) {
 	return kubernetes, nil
 }
 
+// GetKubernetesClusterKubeconfig returns the current kubeconfig for a cluster, fetched fresh from the API.
+// Clusters only get a kubeconfig once they're ACTIVE, until then a KubernetesClusterNotActiveError is returned
+func (c *Client) GetKubernetesClusterKubeconfig(clusterID string) (string, error) {
+	cluster, err := c.GetKubernetesCluster(clusterID)
+	if err != nil {
+		return "", err
+	}
+
+	return kubeconfigFromCluster(cluster)
+}
+
+func kubeconfigFromCluster(cluster *KubernetesCluster) (string, error) {
+	if cluster.KubeConfig != "" {
+		return cluster.KubeConfig, nil
+	}
+
+	if cluster.Status != KubernetesClusterStatusActive {
+		err := fmt.Errorf("cluster %s is %s, the kubeconfig is only available once it is ACTIVE", cluster.ID, cluster.Status)
+		return "", KubernetesClusterNotActiveError.wrap(err)
+	}
+
+	err := fmt.Errorf("cluster %s has no kubeconfig", cluster.ID)
+	return "", KubernetesClusterKubeconfigEmptyError.wrap(err)
+}
+
 // UpdateKubernetesCluster update a single kubernetes cluster by its full ID
 func (c *Client) UpdateKubernetesCluster(id string, i *KubernetesClusterConfig) (*KubernetesCluster, error) {
 	i.Region = c.Region
@@ -305,6 +335,82 @@ func (c *Client) ListKubernetesMarketplaceApplications() ([]KubernetesMarketplac
 	return kubernetes, nil
 }
 
+// InstallKubernetesApplication installs a marketplace application on an existing cluster, returning
+// the updated cluster with the install pending. appName may name a plan as "name:plan" (e.g.
+// "MariaDB:5GB"); both are checked against ListKubernetesMarketplaceApplications first
+func (c *Client) InstallKubernetesApplication(clusterID, appName string) (*KubernetesCluster, error) {
+	app, err := c.findKubernetesMarketplaceApplication(appName)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.UpdateKubernetesCluster(clusterID, &KubernetesClusterConfig{Applications: app})
+}
+
+// RemoveKubernetesApplication uninstalls a marketplace application from a cluster, returning the
+// updated cluster. The application must be installed on the cluster
+func (c *Client) RemoveKubernetesApplication(clusterID, appName string) (*KubernetesCluster, error) {
+	app, err := c.findKubernetesMarketplaceApplication(appName)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := c.GetKubernetesCluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	installed := false
+	for _, a := range cluster.InstalledApplications {
+		if strings.EqualFold(a.Application, app) || strings.EqualFold(a.Name, app) {
+			installed = true
+			break
+		}
+	}
+	if !installed {
+		err := fmt.Errorf("application %s is not installed on cluster %s", app, clusterID)
+		return nil, KubernetesApplicationNotInstalledError.wrap(err)
+	}
+
+	// a leading "-" asks the API to remove the application rather than install it
+	return c.UpdateKubernetesCluster(clusterID, &KubernetesClusterConfig{Applications: "-" + app})
+}
+
+// findKubernetesMarketplaceApplication checks appName ("name" or "name:plan") is in the marketplace,
+// returning it with the marketplace's spelling of the name
+func (c *Client) findKubernetesMarketplaceApplication(appName string) (string, error) {
+	name, plan, hasPlan := strings.Cut(appName, ":")
+	if name == "" {
+		err := fmt.Errorf("the application name is empty")
+		return "", KubernetesApplicationNotFoundError.wrap(err)
+	}
+
+	apps, err := c.ListKubernetesMarketplaceApplications()
+	if err != nil {
+		return "", err
+	}
+
+	for _, app := range apps {
+		if !strings.EqualFold(app.Name, name) {
+			continue
+		}
+		if !hasPlan {
+			return app.Name, nil
+		}
+
+		for _, p := range app.Plans {
+			if strings.EqualFold(p.Label, plan) {
+				return app.Name + ":" + p.Label, nil
+			}
+		}
+		err := fmt.Errorf("application %s has no plan %s", app.Name, plan)
+		return "", KubernetesApplicationNotFoundError.wrap(err)
+	}
+
+	err = fmt.Errorf("application %s is not in the marketplace", name)
+	return "", KubernetesApplicationNotFoundError.wrap(err)
+}
+
 // DeleteKubernetesCluster deletes a cluster
 func (c *Client) DeleteKubernetesCluster(id string) (*SimpleResponse, error) {
 	resp, err := c.SendDeleteRequest(fmt.Sprintf("/v2/kubernetes/clusters/%s", id))
@@ -328,6 +434,34 @@ func (c *Client) RecycleKubernetesCluster(id string, hostname string) (*SimpleRe
 	return c.DecodeSimpleResponse(body)
 }
 
+// RecycleKubernetesNode drains one node of the cluster and replaces it with a fresh one, for
+// remediating an unhealthy node without rebuilding the cluster. nodeID can be the node's ID or
+// hostname; a node that isn't in the cluster returns a ZeroMatchesError and nothing is recycled
+func (c *Client) RecycleKubernetesNode(clusterID, nodeID string) (*SimpleResponse, error) {
+	if len(clusterID) == 0 || len(nodeID) == 0 {
+		err := fmt.Errorf("the cluster or node ID is empty")
+		return nil, IDisEmptyError.wrap(err)
+	}
+
+	cluster, err := c.GetKubernetesCluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := cluster.Instances
+	for _, pool := range cluster.Pools {
+		nodes = append(nodes, pool.Instances...)
+	}
+	for _, node := range nodes {
+		if node.ID == nodeID || node.Hostname == nodeID {
+			return c.RecycleKubernetesCluster(clusterID, node.Hostname)
+		}
+	}
+
+	err = fmt.Errorf("unable to find node %s in cluster %s, zero matches", nodeID, clusterID)
+	return nil, ZeroMatchesError.wrap(err)
+}
+
 // ListAvailableKubernetesVersions returns all version of kubernetes available
 func (c *Client) ListAvailableKubernetesVersions() ([]KubernetesVersion, error) {
 	resp, err := c.SendGetRequest("/v2/kubernetes/versions")
@@ -343,6 +477,82 @@ func (c *Client) ListAvailableKubernetesVersions() ([]KubernetesVersion, error)
 	return kubernetes, nil
 }
 
+// UpgradeKubernetesCluster upgrades a cluster to targetVersion, which must be one of the
+// available versions for the cluster's type. Downgrades, changes of major version and upgrades
+// that skip a minor version (e.g. 1.27 to 1.29) are rejected with a
+// KubernetesClusterInvalidUpgradeError listing the versions the cluster can upgrade to. If the
+// cluster is already on targetVersion it's returned unchanged
+func (c *Client) UpgradeKubernetesCluster(clusterID, targetVersion string) (*KubernetesCluster, error) {
+	cluster, err := c.GetKubernetesCluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := c.ListAvailableKubernetesVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	current := kubernetesSemver(cluster.KubernetesVersion)
+	if current == "" {
+		err := fmt.Errorf("cluster %s is on version %q, which can't be compared", clusterID, cluster.KubernetesVersion)
+		return nil, KubernetesClusterInvalidUpgradeError.wrap(err)
+	}
+
+	var targets []string
+	available := false
+	for _, v := range versions {
+		if cluster.ClusterType != "" && v.ClusterType != "" && !strings.EqualFold(v.ClusterType, cluster.ClusterType) {
+			continue
+		}
+		if !isKubernetesUpgradePath(current, kubernetesSemver(v.Version)) {
+			continue
+		}
+		targets = append(targets, v.Version)
+		if v.Version == targetVersion {
+			available = true
+		}
+	}
+
+	if !available {
+		err := fmt.Errorf("cluster %s can't be upgraded from %s to %s, available versions: %s", clusterID, cluster.KubernetesVersion, targetVersion, strings.Join(targets, ", "))
+		return nil, KubernetesClusterInvalidUpgradeError.wrap(err)
+	}
+
+	if semver.Compare(current, kubernetesSemver(targetVersion)) == 0 {
+		return cluster, nil
+	}
+
+	return c.UpdateKubernetesCluster(clusterID, &KubernetesClusterConfig{KubernetesVersion: targetVersion})
+}
+
+// kubernetesSemver returns version (e.g. "1.28.7-k3s1") in the "v" prefixed form the semver
+// package expects, or "" if it isn't a valid semantic version
+func kubernetesSemver(version string) string {
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	if !semver.IsValid(version) {
+		return ""
+	}
+	return version
+}
+
+// isKubernetesUpgradePath reports whether a cluster on current can move to target: the same
+// major version, no older than current and at most one minor version ahead
+func isKubernetesUpgradePath(current, target string) bool {
+	if target == "" || semver.Major(current) != semver.Major(target) || semver.Compare(target, current) < 0 {
+		return false
+	}
+
+	currentMinor, errCurrent := strconv.Atoi(strings.TrimPrefix(semver.MajorMinor(current), semver.Major(current)+"."))
+	targetMinor, errTarget := strconv.Atoi(strings.TrimPrefix(semver.MajorMinor(target), semver.Major(target)+"."))
+	if errCurrent != nil || errTarget != nil {
+		return false
+	}
+	return targetMinor <= currentMinor+1
+}
+
 // ListKubernetesClusterInstances returns all cluster instances
 func (c *Client) ListKubernetesClusterInstances(id string) ([]Instance, error) {
 	resp, err := c.SendGetRequest(fmt.Sprintf("/v2/kubernetes/clusters/%s/instances", id))
@@ -1,7 +1,12 @@
 package civogo
 
 import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -621,6 +626,37 @@ func TestRecycleKubernetesClusters(t *testing.T) {
 	}
 }
 
+func TestRecycleKubernetesNode(t *testing.T) {
+	var recycled []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "POST" {
+			body := map[string]string{}
+			json.NewDecoder(req.Body).Decode(&body)
+			recycled = append(recycled, req.URL.Path+" "+body["hostname"])
+			rw.Write([]byte(`{"result": "success"}`))
+			return
+		}
+		rw.Write([]byte(`{"id": "12346", "pools": [{"id": "pool-1", "instances": [{"id": "node-1", "hostname": "k3s-pool-1-a"}, {"id": "node-2", "hostname": "k3s-pool-1-b"}]}]}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.RecycleKubernetesNode("12346", "node-2")
+	EnsureSuccessfulSimpleResponse(t, got, err)
+
+	got, err = client.RecycleKubernetesNode("12346", "k3s-pool-1-a")
+	EnsureSuccessfulSimpleResponse(t, got, err)
+
+	if _, err := client.RecycleKubernetesNode("12346", "node-3"); !errors.Is(err, ZeroMatchesError) {
+		t.Errorf("Expected %v, got %v", ZeroMatchesError, err)
+	}
+
+	expected := []string{"/v2/kubernetes/clusters/12346/recycle k3s-pool-1-b", "/v2/kubernetes/clusters/12346/recycle k3s-pool-1-a"}
+	if !reflect.DeepEqual(recycled, expected) {
+		t.Errorf("Expected %v, got %v", expected, recycled)
+	}
+}
+
 func TestListAvailableKubernetesVersions(t *testing.T) {
 	client, server, _ := NewClientForTesting(map[string]string{
 		"/v2/kubernetes/versions": `[
@@ -654,3 +690,139 @@ func TestListAvailableKubernetesVersions(t *testing.T) {
 		t.Errorf("Expected %+v, got %+v", expected, got)
 	}
 }
+
+func TestGetKubernetesClusterKubeconfig(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/kubernetes/clusters/69a23478-a89e-41d2-97b1-6f4c341cee70": `{"id":"69a23478-a89e-41d2-97b1-6f4c341cee70","status":"ACTIVE","kubeconfig":"apiVersion: v1\nkind: Config"}`,
+		"/v2/kubernetes/clusters/building":                             `{"id":"building","status":"BUILDING"}`,
+	})
+	defer server.Close()
+
+	got, err := client.GetKubernetesClusterKubeconfig("69a23478-a89e-41d2-97b1-6f4c341cee70")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got != "apiVersion: v1\nkind: Config" {
+		t.Errorf("Expected %s, got %s", "apiVersion: v1\nkind: Config", got)
+	}
+
+	_, err = client.GetKubernetesClusterKubeconfig("building")
+	if !errors.Is(err, KubernetesClusterNotActiveError) {
+		t.Errorf("Expected %v, got %v", KubernetesClusterNotActiveError, err)
+	}
+}
+
+func TestInstallAndRemoveKubernetesApplication(t *testing.T) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/v2/kubernetes/applications":
+			rw.Write([]byte(`[{"name": "MariaDB", "plans": [{"label": "5GB"}, {"label": "10GB"}]}, {"name": "Traefik"}]`))
+		case req.Method == "GET" && req.URL.Path == "/v2/kubernetes/clusters/69a23478":
+			rw.Write([]byte(`{"id": "69a23478", "installed_applications": [{"application": "Traefik", "name": "Traefik"}]}`))
+		case req.Method == "PUT" && req.URL.Path == "/v2/kubernetes/clusters/69a23478":
+			var config KubernetesClusterConfig
+			json.NewDecoder(req.Body).Decode(&config)
+			sent = append(sent, config.Applications)
+			rw.Write([]byte(`{"id": "69a23478", "status": "ACTIVE"}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.InstallKubernetesApplication("69a23478", "mariadb:5gb")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.ID != "69a23478" {
+		t.Errorf("Expected %s, got %s", "69a23478", got.ID)
+	}
+
+	if _, err := client.RemoveKubernetesApplication("69a23478", "traefik"); err != nil {
+		t.Errorf("Request returned an error: %s", err)
+	}
+
+	expected := []string{"MariaDB:5GB", "-Traefik"}
+	if !reflect.DeepEqual(sent, expected) {
+		t.Errorf("Expected %v, got %v", expected, sent)
+	}
+
+	_, err = client.InstallKubernetesApplication("69a23478", "Unknown")
+	if !errors.Is(err, KubernetesApplicationNotFoundError) {
+		t.Errorf("Expected %v, got %v", KubernetesApplicationNotFoundError, err)
+	}
+
+	_, err = client.InstallKubernetesApplication("69a23478", "MariaDB:1TB")
+	if !errors.Is(err, KubernetesApplicationNotFoundError) {
+		t.Errorf("Expected %v, got %v", KubernetesApplicationNotFoundError, err)
+	}
+
+	_, err = client.RemoveKubernetesApplication("69a23478", "MariaDB")
+	if !errors.Is(err, KubernetesApplicationNotInstalledError) {
+		t.Errorf("Expected %v, got %v", KubernetesApplicationNotInstalledError, err)
+	}
+	if len(sent) != 2 {
+		t.Errorf("Expected no further updates, got %v", sent)
+	}
+}
+
+func TestUpgradeKubernetesCluster(t *testing.T) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/v2/kubernetes/versions":
+			rw.Write([]byte(`[
+				{"version": "1.26.4-k3s1", "type": "deprecated", "clusterType": "k3s"},
+				{"version": "1.27.1-k3s1", "type": "stable", "clusterType": "k3s"},
+				{"version": "1.27.5-k3s1", "type": "stable", "clusterType": "k3s"},
+				{"version": "1.28.2-k3s1", "type": "stable", "clusterType": "k3s", "default": true},
+				{"version": "1.29.0-k3s1", "type": "development", "clusterType": "k3s"},
+				{"version": "1.28.2", "type": "stable", "clusterType": "talos"}
+			]`))
+		case req.Method == "GET" && req.URL.Path == "/v2/kubernetes/clusters/69a23478":
+			rw.Write([]byte(`{"id": "69a23478", "cluster_type": "k3s", "kubernetes_version": "1.27.1-k3s1"}`))
+		case req.Method == "PUT" && req.URL.Path == "/v2/kubernetes/clusters/69a23478":
+			var config KubernetesClusterConfig
+			json.NewDecoder(req.Body).Decode(&config)
+			sent = append(sent, config.KubernetesVersion)
+			rw.Write([]byte(`{"id": "69a23478", "cluster_type": "k3s", "kubernetes_version": "` + config.KubernetesVersion + `"}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.UpgradeKubernetesCluster("69a23478", "1.28.2-k3s1")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.KubernetesVersion != "1.28.2-k3s1" {
+		t.Errorf("Expected %s, got %s", "1.28.2-k3s1", got.KubernetesVersion)
+	}
+
+	// already on the version, nothing to do
+	if _, err := client.UpgradeKubernetesCluster("69a23478", "1.27.1-k3s1"); err != nil {
+		t.Errorf("Request returned an error: %s", err)
+	}
+
+	for _, target := range []string{"1.26.4-k3s1", "1.29.0-k3s1", "1.28.2", "1.30.0-k3s1"} {
+		_, err := client.UpgradeKubernetesCluster("69a23478", target)
+		if !errors.Is(err, KubernetesClusterInvalidUpgradeError) {
+			t.Errorf("Expected %v upgrading to %s, got %v", KubernetesClusterInvalidUpgradeError, target, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), "available versions: 1.27.1-k3s1, 1.27.5-k3s1, 1.28.2-k3s1") {
+			t.Errorf("Expected the error to list the available versions, got %s", err)
+		}
+	}
+
+	if !reflect.DeepEqual(sent, []string{"1.28.2-k3s1"}) {
+		t.Errorf("Expected only one update, got %v", sent)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 )
 
 // HealthCheck represents the health check configuration for an instance pool.
@@ -15,11 +16,22 @@ type HealthCheck struct {
 
 // LoadBalancerBackend represents a backend instance being load-balanced
 type LoadBalancerBackend struct {
-	IP              string `json:"ip"`
-	Protocol        string `json:"protocol,omitempty"`
-	SourcePort      int32  `json:"source_port"`
-	TargetPort      int32  `json:"target_port"`
-	HealthCheckPort int32  `json:"health_check_port,omitempty"`
+	IP              string     `json:"ip"`
+	Protocol        string     `json:"protocol,omitempty"`
+	SourcePort      int32      `json:"source_port"`
+	TargetPort      int32      `json:"target_port"`
+	HealthCheckPort int32      `json:"health_check_port,omitempty"`
+	Status          string     `json:"status,omitempty"`
+	LastCheckedAt   *time.Time `json:"last_checked_at,omitempty"`
+}
+
+// BackendHealth is the health of a single load balancer backend, as last reported by the API
+type BackendHealth struct {
+	IP            string    `json:"ip"`
+	Port          int32     `json:"port"`
+	Status        string    `json:"status"`
+	Healthy       bool      `json:"healthy"`
+	LastCheckedAt time.Time `json:"last_checked_at"`
 }
 
 // InstancePool represents an instance pool configuration in a load balancer.
@@ -149,6 +161,36 @@ func (c *Client) GetLoadBalancer(id string) (*LoadBalancer, error) {
 	return loadbalancer, nil
 }
 
+// GetLoadBalancerBackendHealth returns the health of each backend of a load balancer. The API
+// reports health as a status on each backend, so a backend is Healthy when that status is "healthy"
+func (c *Client) GetLoadBalancerBackendHealth(lbID string) ([]BackendHealth, error) {
+	if len(lbID) == 0 {
+		err := fmt.Errorf("the load balancer ID is empty")
+		return nil, IDisEmptyError.wrap(err)
+	}
+
+	loadbalancer, err := c.GetLoadBalancer(lbID)
+	if err != nil {
+		return nil, err
+	}
+
+	health := make([]BackendHealth, 0, len(loadbalancer.Backends))
+	for _, backend := range loadbalancer.Backends {
+		backendHealth := BackendHealth{
+			IP:      backend.IP,
+			Port:    backend.TargetPort,
+			Status:  backend.Status,
+			Healthy: strings.EqualFold(backend.Status, "healthy"),
+		}
+		if backend.LastCheckedAt != nil {
+			backendHealth.LastCheckedAt = *backend.LastCheckedAt
+		}
+		health = append(health, backendHealth)
+	}
+
+	return health, nil
+}
+
 // FindLoadBalancer finds a load balancer by either part of the ID or part of the name
 func (c *Client) FindLoadBalancer(search string) (*LoadBalancer, error) {
 	lbs, err := c.ListLoadBalancers()
@@ -198,6 +240,162 @@ func (c *Client) CreateLoadBalancer(r *LoadBalancerConfig) (*LoadBalancer, error
 	return loadbalancer, nil
 }
 
+// LoadBalancerBackendChange is a backend whose settings differ between two configs
+type LoadBalancerBackendChange struct {
+	Current LoadBalancerBackendConfig `json:"current"`
+	Desired LoadBalancerBackendConfig `json:"desired"`
+}
+
+// LoadBalancerDiff is what an update would change on a load balancer. Backends are matched by IP
+// and source port, so the order they are listed in doesn't matter. ChangedFields holds the JSON
+// names of any other settings that would change, e.g. "algorithm"
+type LoadBalancerDiff struct {
+	AddedBackends   []LoadBalancerBackendConfig `json:"added_backends,omitempty"`
+	RemovedBackends []LoadBalancerBackendConfig `json:"removed_backends,omitempty"`
+	ChangedBackends []LoadBalancerBackendChange `json:"changed_backends,omitempty"`
+	ChangedFields   []string                    `json:"changed_fields,omitempty"`
+}
+
+// IsEmpty reports whether the diff has no changes at all
+func (d *LoadBalancerDiff) IsEmpty() bool {
+	return len(d.AddedBackends) == 0 && len(d.RemovedBackends) == 0 && len(d.ChangedBackends) == 0 && len(d.ChangedFields) == 0
+}
+
+// DiffLoadBalancer works out what updating a load balancer configured as current with desired
+// would change. As with UpdateLoadBalancer, fields left empty in desired are left as they are, so
+// only the backends are compared when desired has some. A backend listed twice in either config
+// gives a LoadBalancerDuplicateBackendError
+func DiffLoadBalancer(current, desired LoadBalancerUpdateConfig) (*LoadBalancerDiff, error) {
+	diff := &LoadBalancerDiff{}
+
+	changed := func(field string, isSet, differs bool) {
+		if isSet && differs {
+			diff.ChangedFields = append(diff.ChangedFields, field)
+		}
+	}
+	changed("name", desired.Name != "", desired.Name != current.Name)
+	changed("service_name", desired.ServiceName != "", desired.ServiceName != current.ServiceName)
+	changed("algorithm", desired.Algorithm != "", desired.Algorithm != current.Algorithm)
+	changed("external_traffic_policy", desired.ExternalTrafficPolicy != "", desired.ExternalTrafficPolicy != current.ExternalTrafficPolicy)
+	changed("session_affinity", desired.SessionAffinity != "", desired.SessionAffinity != current.SessionAffinity)
+	changed("session_affinity_config_timeout", desired.SessionAffinityConfigTimeout != 0, desired.SessionAffinityConfigTimeout != current.SessionAffinityConfigTimeout)
+	changed("enable_proxy_protocol", desired.EnableProxyProtocol != "", desired.EnableProxyProtocol != current.EnableProxyProtocol)
+	changed("firewall_id", desired.FirewallID != "", desired.FirewallID != current.FirewallID)
+	if desired.MaxConcurrentRequests != nil {
+		changed("max_concurrent_requests", true, current.MaxConcurrentRequests == nil || *desired.MaxConcurrentRequests != *current.MaxConcurrentRequests)
+	}
+	if desired.LoadBalancerOptions != nil {
+		changed("options", true, current.LoadBalancerOptions == nil || *desired.LoadBalancerOptions != *current.LoadBalancerOptions)
+	}
+	changed("instance_pools", desired.InstancePools != nil, !sameInstancePools(current.InstancePools, desired.InstancePools))
+
+	if desired.Backends == nil {
+		return diff, nil
+	}
+
+	currentBackends, err := backendsByKey(current.Backends)
+	if err != nil {
+		return nil, err
+	}
+	desiredBackends, err := backendsByKey(desired.Backends)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, backend := range desired.Backends {
+		existing, ok := currentBackends[loadBalancerBackendKey(backend)]
+		if !ok {
+			diff.AddedBackends = append(diff.AddedBackends, backend)
+		} else if !strings.EqualFold(existing.Protocol, backend.Protocol) ||
+			existing.TargetPort != backend.TargetPort ||
+			existing.HealthCheckPort != backend.HealthCheckPort {
+			diff.ChangedBackends = append(diff.ChangedBackends, LoadBalancerBackendChange{Current: existing, Desired: backend})
+		}
+	}
+	for _, backend := range current.Backends {
+		if _, ok := desiredBackends[loadBalancerBackendKey(backend)]; !ok {
+			diff.RemovedBackends = append(diff.RemovedBackends, backend)
+		}
+	}
+
+	return diff, nil
+}
+
+// loadBalancerBackendKey identifies a backend by its IP and source port
+func loadBalancerBackendKey(b LoadBalancerBackendConfig) string {
+	return fmt.Sprintf("%s:%d", b.IP, b.SourcePort)
+}
+
+// backendsByKey indexes backends by loadBalancerBackendKey, rejecting duplicates
+func backendsByKey(backends []LoadBalancerBackendConfig) (map[string]LoadBalancerBackendConfig, error) {
+	result := make(map[string]LoadBalancerBackendConfig, len(backends))
+	for _, backend := range backends {
+		key := loadBalancerBackendKey(backend)
+		if _, ok := result[key]; ok {
+			err := fmt.Errorf("backend %s is listed more than once", key)
+			return nil, LoadBalancerDuplicateBackendError.wrap(err)
+		}
+		result[key] = backend
+	}
+
+	return result, nil
+}
+
+// sameInstancePools reports whether a and b hold the same instance pools, in any order
+func sameInstancePools(a, b []LoadBalancerInstancePoolConfig) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int, len(a))
+	for _, pool := range a {
+		encoded, _ := json.Marshal(pool)
+		counts[string(encoded)]++
+	}
+	for _, pool := range b {
+		encoded, _ := json.Marshal(pool)
+		counts[string(encoded)]--
+		if counts[string(encoded)] < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// loadBalancerUpdateConfigFrom returns the update config that describes lb as it is now
+func loadBalancerUpdateConfigFrom(lb *LoadBalancer) LoadBalancerUpdateConfig {
+	config := LoadBalancerUpdateConfig{
+		Name:                         lb.Name,
+		ServiceName:                  lb.ServiceName,
+		Algorithm:                    lb.Algorithm,
+		ExternalTrafficPolicy:        lb.ExternalTrafficPolicy,
+		SessionAffinity:              lb.SessionAffinity,
+		SessionAffinityConfigTimeout: lb.SessionAffinityConfigTimeout,
+		EnableProxyProtocol:          lb.EnableProxyProtocol,
+		FirewallID:                   lb.FirewallID,
+		LoadBalancerOptions:          lb.Options,
+	}
+
+	maxConcurrentRequests := lb.MaxConcurrentRequests
+	config.MaxConcurrentRequests = &maxConcurrentRequests
+
+	for _, b := range lb.Backends {
+		config.Backends = append(config.Backends, LoadBalancerBackendConfig{
+			IP:              b.IP,
+			Protocol:        b.Protocol,
+			SourcePort:      b.SourcePort,
+			TargetPort:      b.TargetPort,
+			HealthCheckPort: b.HealthCheckPort,
+		})
+	}
+	for _, p := range lb.InstancePool {
+		config.InstancePools = append(config.InstancePools, LoadBalancerInstancePoolConfig(p))
+	}
+
+	return config
+}
+
 // UpdateLoadBalancer updates a load balancer
 func (c *Client) UpdateLoadBalancer(id string, r *LoadBalancerUpdateConfig) (*LoadBalancer, error) {
 	body, err := c.SendPutRequest(fmt.Sprintf("/v2/loadbalancers/%s", id), r)
@@ -213,6 +411,25 @@ func (c *Client) UpdateLoadBalancer(id string, r *LoadBalancerUpdateConfig) (*Lo
 	return loadbalancer, nil
 }
 
+// UpdateLoadBalancerIfChanged is UpdateLoadBalancer, except the load balancer is fetched first, and
+// if r wouldn't change anything (see DiffLoadBalancer) it is returned as is without an update
+func (c *Client) UpdateLoadBalancerIfChanged(id string, r *LoadBalancerUpdateConfig) (*LoadBalancer, error) {
+	current, err := c.GetLoadBalancer(id)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := DiffLoadBalancer(loadBalancerUpdateConfigFrom(current), *r)
+	if err != nil {
+		return nil, err
+	}
+	if diff.IsEmpty() {
+		return current, nil
+	}
+
+	return c.UpdateLoadBalancer(id, r)
+}
+
 // DeleteLoadBalancer deletes a load balancer
 func (c *Client) DeleteLoadBalancer(id string) (*SimpleResponse, error) {
 	resp, err := c.SendDeleteRequest(fmt.Sprintf("/v2/loadbalancers/%s", id))
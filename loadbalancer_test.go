@@ -1,8 +1,12 @@
 package civogo
 
 import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestListLoadBalancers(t *testing.T) {
@@ -236,6 +240,90 @@ func TestUpdateLoadBalancer(t *testing.T) {
 	}
 }
 
+func TestDiffLoadBalancer(t *testing.T) {
+	current := LoadBalancerUpdateConfig{
+		Name:      "test-lb",
+		Algorithm: "round_robin",
+		Backends: []LoadBalancerBackendConfig{
+			{IP: "192.168.1.3", Protocol: "TCP", SourcePort: 80, TargetPort: 31579},
+			{IP: "192.168.1.4", Protocol: "TCP", SourcePort: 80, TargetPort: 31579},
+		},
+	}
+
+	reordered := LoadBalancerUpdateConfig{
+		Name: "test-lb",
+		Backends: []LoadBalancerBackendConfig{
+			{IP: "192.168.1.4", Protocol: "tcp", SourcePort: 80, TargetPort: 31579},
+			{IP: "192.168.1.3", Protocol: "TCP", SourcePort: 80, TargetPort: 31579},
+		},
+	}
+	got, err := DiffLoadBalancer(current, reordered)
+	if err != nil {
+		t.Errorf("Diffing returned an error: %s", err)
+		return
+	}
+	if !got.IsEmpty() {
+		t.Errorf("Expected reordered backends not to be a change, got %+v", got)
+	}
+
+	desired := LoadBalancerUpdateConfig{
+		Algorithm: "least_connections",
+		Backends: []LoadBalancerBackendConfig{
+			{IP: "192.168.1.4", Protocol: "TCP", SourcePort: 80, TargetPort: 31580},
+			{IP: "192.168.1.5", Protocol: "TCP", SourcePort: 80, TargetPort: 31579},
+		},
+	}
+	got, err = DiffLoadBalancer(current, desired)
+	if err != nil {
+		t.Errorf("Diffing returned an error: %s", err)
+		return
+	}
+	expected := &LoadBalancerDiff{
+		AddedBackends:   []LoadBalancerBackendConfig{desired.Backends[1]},
+		RemovedBackends: []LoadBalancerBackendConfig{current.Backends[0]},
+		ChangedBackends: []LoadBalancerBackendChange{{Current: current.Backends[1], Desired: desired.Backends[0]}},
+		ChangedFields:   []string{"algorithm"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+
+	desired.Backends = append(desired.Backends, desired.Backends[0])
+	if _, err := DiffLoadBalancer(current, desired); !errors.Is(err, LoadBalancerDuplicateBackendError) {
+		t.Errorf("Expected %v, got %v", LoadBalancerDuplicateBackendError, err)
+	}
+}
+
+func TestUpdateLoadBalancerIfChanged(t *testing.T) {
+	updates := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "PUT" {
+			updates++
+		}
+		rw.Write([]byte(`{"id": "12345", "name": "test-lb", "algorithm": "round_robin", "backends": [{"ip": "192.168.1.3", "protocol": "TCP", "source_port": 80, "target_port": 31579}]}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	_, err := client.UpdateLoadBalancerIfChanged("12345", &LoadBalancerUpdateConfig{Name: "test-lb", Algorithm: "round_robin"})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if updates != 0 {
+		t.Errorf("Expected no update to be sent, got %d", updates)
+	}
+
+	_, err = client.UpdateLoadBalancerIfChanged("12345", &LoadBalancerUpdateConfig{Algorithm: "least_connections"})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if updates != 1 {
+		t.Errorf("Expected 1 update to be sent, got %d", updates)
+	}
+}
+
 func TestGetLoadBalancer(t *testing.T) {
 	client, server, _ := NewClientForTesting(map[string]string{
 		"/v2/loadbalancers/a1bd123c-b7e2-4d4f-9fda-7940c7e06b38": `{
@@ -298,6 +386,60 @@ func TestGetLoadBalancer(t *testing.T) {
 		t.Errorf("Expected %+v, got %+v", expected, got)
 	}
 }
+func TestGetLoadBalancerBackendHealth(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/loadbalancers/12345": `{
+			"id": "12345",
+			"name": "test-lb",
+			"backends": [
+				{
+					"ip": "192.168.1.3",
+					"protocol": "TCP",
+					"source_port": 80,
+					"target_port": 31579,
+					"status": "healthy",
+					"last_checked_at": "2026-10-15T10:00:00Z"
+				},
+				{
+					"ip": "192.168.1.4",
+					"protocol": "TCP",
+					"source_port": 80,
+					"target_port": 31579,
+					"status": "unhealthy",
+					"last_checked_at": "2026-10-15T10:00:05Z"
+				}
+			]
+		}`,
+	})
+	defer server.Close()
+
+	got, err := client.GetLoadBalancerBackendHealth("12345")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+
+	expected := []BackendHealth{
+		{
+			IP:            "192.168.1.3",
+			Port:          31579,
+			Status:        "healthy",
+			Healthy:       true,
+			LastCheckedAt: time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			IP:            "192.168.1.4",
+			Port:          31579,
+			Status:        "unhealthy",
+			Healthy:       false,
+			LastCheckedAt: time.Date(2026, 10, 15, 10, 0, 5, 0, time.UTC),
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
 func TestDeleteLoadBalancer(t *testing.T) {
 	client, server, _ := NewClientForTesting(map[string]string{
 		"/v2/loadbalancers/12345": `{"result": "success"}`,
@@ -0,0 +1,26 @@
+package civogo
+
+import "time"
+
+// MetricsCollector is told about every request the client makes to the API, so per-endpoint
+// latency and error rates can be recorded in whichever metrics library the caller uses
+type MetricsCollector interface {
+	// ObserveRequest is called once each Send*Request call completes, with the request's method,
+	// URL path (without the query string), the final HTTP status (0 if no response was received)
+	// and how long it took, including any retries
+	ObserveRequest(method, path string, status int, dur time.Duration)
+}
+
+// noopMetricsCollector is the default MetricsCollector, it discards everything
+type noopMetricsCollector struct{}
+
+func (noopMetricsCollector) ObserveRequest(method, path string, status int, dur time.Duration) {}
+
+// SetMetricsCollector makes the client report every API request to collector. A nil collector
+// turns reporting off again
+func (c *Client) SetMetricsCollector(collector MetricsCollector) {
+	if collector == nil {
+		collector = noopMetricsCollector{}
+	}
+	c.metrics = collector
+}
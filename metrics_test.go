@@ -0,0 +1,65 @@
+package civogo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+type observedRequest struct {
+	method, path string
+	status       int
+}
+
+type recordingMetricsCollector struct {
+	observed []observedRequest
+}
+
+func (r *recordingMetricsCollector) ObserveRequest(method, path string, status int, dur time.Duration) {
+	r.observed = append(r.observed, observedRequest{method: method, path: path, status: status})
+}
+
+func TestMetricsCollector(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "DELETE" {
+			rw.WriteHeader(http.StatusNotFound)
+			rw.Write([]byte(`{"code":"database_network_not_found"}`))
+			return
+		}
+		rw.Write([]byte(`{"result":"success"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientForTestingWithServer(server)
+	g.Expect(err).To(BeNil())
+
+	// the default collector discards everything
+	_, err = client.SendGetRequest("/v2/ping")
+	g.Expect(err).To(BeNil())
+
+	collector := &recordingMetricsCollector{}
+	client.SetMetricsCollector(collector)
+
+	_, err = client.SendGetRequest("/v2/ping")
+	g.Expect(err).To(BeNil())
+	_, err = client.SendPostRequest("/v2/networks", nil)
+	g.Expect(err).To(BeNil())
+	_, err = client.SendDeleteRequest("/v2/networks/12345")
+	g.Expect(err).ToNot(BeNil())
+
+	g.Expect(collector.observed).To(Equal([]observedRequest{
+		{method: "GET", path: "/v2/ping", status: http.StatusOK},
+		{method: "POST", path: "/v2/networks", status: http.StatusOK},
+		{method: "DELETE", path: "/v2/networks/12345", status: http.StatusNotFound},
+	}))
+
+	client.SetMetricsCollector(nil)
+	_, err = client.SendGetRequest("/v2/ping")
+	g.Expect(err).To(BeNil())
+	g.Expect(len(collector.observed)).To(Equal(3))
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"strings"
 )
 
@@ -98,7 +99,8 @@ type NetworkResult struct {
 	Result string `json:"result"`
 }
 
-// GetDefaultNetwork finds the default private network for an account
+// GetDefaultNetwork finds the default private network for an account, the one new instances and
+// clusters go in unless told otherwise. It returns a ZeroMatchesError if no network is the default
 func (c *Client) GetDefaultNetwork() (*Network, error) {
 	resp, err := c.SendGetRequest("/v2/networks")
 	if err != nil {
@@ -106,14 +108,17 @@ func (c *Client) GetDefaultNetwork() (*Network, error) {
 	}
 
 	networks := make([]Network, 0)
-	json.NewDecoder(bytes.NewReader(resp)).Decode(&networks)
+	if err := json.NewDecoder(bytes.NewReader(resp)).Decode(&networks); err != nil {
+		return nil, err
+	}
 	for _, network := range networks {
 		if network.Default {
 			return &network, nil
 		}
 	}
 
-	return nil, errors.New("no default network found")
+	err = errors.New("no default network found")
+	return nil, ZeroMatchesError.wrap(err)
 }
 
 // GetNetwork gets a network with ID
@@ -347,6 +352,59 @@ func (c *Client) CreateNetwork(nc NetworkConfig) (*NetworkResult, error) {
 	return result, nil
 }
 
+// CreateNetworkSafe creates a new network like CreateNetwork, but first checks the requested IPv4
+// CIDR (and the VLAN's CIDR, if connecting to one) doesn't overlap any existing network's,
+// returning NetworkCIDROverlapError naming the conflicting network if it does
+func (c *Client) CreateNetworkSafe(nc NetworkConfig) (*NetworkResult, error) {
+	requested := []string{nc.CIDRv4}
+	if nc.VLanConfig != nil {
+		requested = append(requested, nc.VLanConfig.CIDRv4)
+	}
+
+	networks, err := c.ListNetworks()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cidr := range requested {
+		if cidr == "" {
+			continue
+		}
+
+		for _, network := range networks {
+			if network.CIDR == "" {
+				continue
+			}
+
+			overlap, err := CIDRsOverlap(cidr, network.CIDR)
+			if err != nil {
+				return nil, err
+			}
+			if overlap {
+				err := fmt.Errorf("%s overlaps %s of network %s (%s)", cidr, network.CIDR, network.Label, network.ID)
+				return nil, NetworkCIDROverlapError.wrap(err)
+			}
+		}
+	}
+
+	return c.CreateNetwork(nc)
+}
+
+// CIDRsOverlap reports whether the two CIDR ranges share any addresses. Ranges from different IP
+// families never overlap
+func CIDRsOverlap(a, b string) (bool, error) {
+	_, netA, err := net.ParseCIDR(a)
+	if err != nil {
+		return false, err
+	}
+	_, netB, err := net.ParseCIDR(b)
+	if err != nil {
+		return false, err
+	}
+
+	return netA.Contains(netB.IP) || netB.Contains(netA.IP), nil
+}
+
 // UpdateNetwork updates an existing network
 func (c *Client) UpdateNetwork(id string, nc NetworkConfig) (*NetworkResult, error) {
 	body, err := c.SendPutRequest("/v2/networks/"+id, nc)
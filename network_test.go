@@ -1,7 +1,11 @@
 package civogo
 
 import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -24,6 +28,18 @@ func TestGetDefaultNetwork(t *testing.T) {
 	}
 }
 
+func TestGetDefaultNetworkNoDefault(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/networks": `[{"id": "12345", "default": false, "name": "other-network"}]`,
+	})
+	defer server.Close()
+
+	_, err := client.GetDefaultNetwork()
+	if !errors.Is(err, ZeroMatchesError) {
+		t.Errorf("Expected %v, got %v", ZeroMatchesError, err)
+	}
+}
+
 func TestGetNetwork(t *testing.T) {
 	client, server, _ := NewClientForTesting(map[string]string{
 		"/v2/networks/12345": `{"id": "12345", "name": "test-network"}`,
@@ -100,6 +116,64 @@ func TestCreateNetwork(t *testing.T) {
 	}
 }
 
+func TestCreateNetworkSafe(t *testing.T) {
+	created := false
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "POST" {
+			created = true
+			rw.Write([]byte(`{"id": "67890", "result": "success", "label": "new-net"}`))
+			return
+		}
+		rw.Write([]byte(`[{"id": "12345", "label": "existing-net", "cidr": "10.0.0.0/16"}, {"id": "23456", "label": "default"}]`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	_, err := client.CreateNetworkSafe(NetworkConfig{Label: "new-net", CIDRv4: "10.0.5.0/24"})
+	if !errors.Is(err, NetworkCIDROverlapError) || !strings.Contains(err.Error(), "existing-net") {
+		t.Errorf("Expected %v naming existing-net, got %v", NetworkCIDROverlapError, err)
+	}
+	if created {
+		t.Errorf("Expected the overlapping network not to be created")
+	}
+
+	got, err := client.CreateNetworkSafe(NetworkConfig{Label: "new-net", CIDRv4: "10.1.0.0/24"})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if !created || got.ID != "67890" {
+		t.Errorf("Expected network 67890 to be created, got %+v", got)
+	}
+}
+
+func TestCIDRsOverlap(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"10.0.0.0/16", "10.0.5.0/24", true},
+		{"10.0.5.0/24", "10.0.0.0/16", true},
+		{"10.0.0.0/24", "10.0.1.0/24", false},
+		{"192.168.1.0/24", "192.168.1.128/25", true},
+		{"10.0.0.0/8", "fd00::/8", false},
+	}
+	for _, test := range tests {
+		got, err := CIDRsOverlap(test.a, test.b)
+		if err != nil {
+			t.Errorf("Checking %s and %s returned an error: %s", test.a, test.b, err)
+			continue
+		}
+		if got != test.expected {
+			t.Errorf("Expected %t for %s and %s, got %t", test.expected, test.a, test.b, got)
+		}
+	}
+
+	if _, err := CIDRsOverlap("10.0.0.0", "10.0.0.0/8"); err == nil {
+		t.Errorf("Expected an error for an invalid CIDR")
+	}
+}
+
 func TestCreateNetworkWithVLAN(t *testing.T) {
 	client, server, _ := NewClientForTesting(map[string]string{
 		"/v2/networks": `{
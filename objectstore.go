@@ -47,6 +47,21 @@ type ObjectStoreStats struct {
 	NumObjects     int64 `json:"num_objects"`
 }
 
+// BytesUsed returns the space used by the objectstore in bytes
+func (s *ObjectStoreStats) BytesUsed() int64 {
+	return s.SizeKBUtilised * 1024
+}
+
+// ObjectCount returns the number of objects in the objectstore
+func (s *ObjectStoreStats) ObjectCount() int64 {
+	return s.NumObjects
+}
+
+// MaxSizeGB returns the size allocated to the objectstore in GB
+func (s *ObjectStoreStats) MaxSizeGB() int64 {
+	return s.MaxSizeKB / (1024 * 1024)
+}
+
 // UpdateObjectStoreRequest holds the request to update a specified object storage's details
 type UpdateObjectStoreRequest struct {
 	MaxSizeGB int64 `json:"max_size_gb"`
@@ -159,7 +174,8 @@ func (c *Client) DeleteObjectStore(id string) (*SimpleResponse, error) {
 	return c.DecodeSimpleResponse(resp)
 }
 
-// GetObjectStoreStats returns the stats for an objectstore
+// GetObjectStoreStats returns the stats for an objectstore. The usage is gathered periodically
+// rather than on each request, so it may lag behind recent uploads and deletes by a few minutes
 func (c *Client) GetObjectStoreStats(id string) (*ObjectStoreStats, error) {
 	resp, err := c.SendGetRequest(fmt.Sprintf("/v2/objectstores/%s/stats", id))
 	if err != nil {
@@ -2,8 +2,10 @@ package civogo
 
 import (
 	"bytes"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"strings"
 )
 
@@ -142,6 +144,63 @@ func (c *Client) UpdateObjectStoreCredential(id string, v *UpdateObjectStoreCred
 	return result, nil
 }
 
+// secretAccessKeyAlphabet and secretAccessKeyLength describe the 40 character secret keys generated on rotation
+const (
+	secretAccessKeyAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	secretAccessKeyLength   = 40
+)
+
+// RotateObjectStoreCredential replaces the secret key of an objectstore credential with a freshly
+// generated one, keeping the same access key so consumers only need the new secret. The returned
+// credential holds the new secret. There is no overlap between the two secrets: the old one stops
+// working as soon as the update is applied, so consumers should be updated straight after
+func (c *Client) RotateObjectStoreCredential(id string) (*ObjectStoreCredential, error) {
+	if len(id) == 0 {
+		err := fmt.Errorf("the objectstore credential ID is empty")
+		return nil, IDisEmptyError.wrap(err)
+	}
+
+	current, err := c.GetObjectStoreCredential(id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := newSecretAccessKey()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.UpdateObjectStoreCredential(id, &UpdateObjectStoreCredentialRequest{
+		AccessKeyID:       &current.AccessKeyID,
+		SecretAccessKeyID: &secret,
+		Region:            c.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.SecretAccessKeyID == "" {
+		result.SecretAccessKeyID = secret
+	}
+
+	return result, nil
+}
+
+// newSecretAccessKey generates a random secret key for an objectstore credential
+func newSecretAccessKey() (string, error) {
+	max := big.NewInt(int64(len(secretAccessKeyAlphabet)))
+	secret := make([]byte, secretAccessKeyLength)
+	for i := range secret {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		secret[i] = secretAccessKeyAlphabet[n.Int64()]
+	}
+
+	return string(secret), nil
+}
+
 // DeleteObjectStoreCredential deletes an objectstore credential
 func (c *Client) DeleteObjectStoreCredential(id string) (*SimpleResponse, error) {
 	resp, err := c.SendDeleteRequest(fmt.Sprintf("/v2/objectstore/credentials/%s", id))
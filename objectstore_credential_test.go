@@ -1,6 +1,9 @@
 package civogo
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 )
@@ -137,3 +140,34 @@ func TestDeleteObjectStoreCredential(t *testing.T) {
 func intPtr(i int) *int {
 	return &i
 }
+
+func TestRotateObjectStoreCredential(t *testing.T) {
+	var update UpdateObjectStoreCredentialRequest
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "PUT" {
+			json.NewDecoder(req.Body).Decode(&update)
+			rw.Write([]byte(`{"id": "12345", "name": "test-objectstore-cred", "access_key_id": "ABC123", "status": "ready"}`))
+			return
+		}
+		rw.Write([]byte(`{"id": "12345", "name": "test-objectstore-cred", "access_key_id": "ABC123", "secret_access_key_id": "old-secret", "status": "ready"}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.RotateObjectStoreCredential("12345")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+
+	if update.AccessKeyID == nil || *update.AccessKeyID != "ABC123" {
+		t.Errorf("Expected the access key %s to be kept, got %v", "ABC123", update.AccessKeyID)
+	}
+	if update.SecretAccessKeyID == nil || len(*update.SecretAccessKeyID) != 40 || *update.SecretAccessKeyID == "old-secret" {
+		t.Errorf("Expected a new 40 character secret, got %v", update.SecretAccessKeyID)
+		return
+	}
+	if got.AccessKeyID != "ABC123" || got.SecretAccessKeyID != *update.SecretAccessKeyID {
+		t.Errorf("Expected the new key pair to be returned, got %+v", got)
+	}
+}
@@ -135,3 +135,25 @@ func TestDeleteObjectStore(t *testing.T) {
 		t.Errorf("Expected %+v, got %+v", expected, got)
 	}
 }
+
+func TestGetObjectStoreStats(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/objectstores/12345/stats": `{"size_kb_utilised": 2048, "max_size_kb": 524288000, "num_objects": 7}`,
+	})
+	defer server.Close()
+
+	got, err := client.GetObjectStoreStats("12345")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+
+	expected := &ObjectStoreStats{SizeKBUtilised: 2048, MaxSizeKB: 524288000, NumObjects: 7}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+
+	if got.BytesUsed() != 2097152 || got.ObjectCount() != 7 || got.MaxSizeGB() != 500 {
+		t.Errorf("Expected 2097152 bytes, 7 objects and 500 GB, got %d, %d and %d", got.BytesUsed(), got.ObjectCount(), got.MaxSizeGB())
+	}
+}
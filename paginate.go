@@ -0,0 +1,66 @@
+package civogo
+
+import "errors"
+
+// eachPageSize is how many items the Each helpers request per page
+const eachPageSize = 100
+
+// eachItem calls fn for every item fetched by fetchPage, starting at page 1 and carrying on until
+// the last page reported. It stops as soon as fetchPage or fn returns an error; StopIterationError
+// from fn stops the walk without being returned
+func eachItem[T any](fetchPage func(page int) (items []T, pages int, err error), fn func(T) error) error {
+	for page := 1; ; page++ {
+		items, pages, err := fetchPage(page)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				if errors.Is(err, StopIterationError) {
+					return nil
+				}
+				return err
+			}
+		}
+
+		// a page can be empty without being the last, e.g. disk images filtered out of it
+		if page >= pages {
+			return nil
+		}
+	}
+}
+
+// EachInstance calls fn for each instance owned by the calling API account, fetching them a
+// page at a time rather than all at once. Return StopIterationError from fn to stop early
+func (c *Client) EachInstance(fn func(Instance) error) error {
+	return eachItem(func(page int) ([]Instance, int, error) {
+		instances, err := c.ListInstances(page, eachPageSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		return instances.Items, instances.Pages, nil
+	}, fn)
+}
+
+// EachDiskImage calls fn for each disk image, with the same k3s/talos filtering as ListDiskImages,
+// fetching them a page at a time rather than all at once. Return StopIterationError from fn to
+// stop early
+func (c *Client) EachDiskImage(fn func(DiskImage) error) error {
+	return eachItem(func(page int) ([]DiskImage, int, error) {
+		images, err := c.PaginatedListDiskImages(page, eachPageSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		return images.Items, images.Pages, nil
+	}, fn)
+}
+
+// EachVolume calls fn for each volume. The volumes endpoint isn't paginated, so they are fetched
+// in one request. Return StopIterationError from fn to stop early
+func (c *Client) EachVolume(fn func(Volume) error) error {
+	return eachItem(func(page int) ([]Volume, int, error) {
+		volumes, err := c.ListVolumes()
+		return volumes, 1, err
+	}, fn)
+}
@@ -0,0 +1,115 @@
+package civogo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEachInstance(t *testing.T) {
+	var pagesFetched []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		page := req.URL.Query().Get("page")
+		pagesFetched = append(pagesFetched, page)
+		fmt.Fprintf(rw, `{"page": %s, "per_page": 2, "pages": 2, "items": [{"id": "%s-1"}, {"id": "%s-2"}]}`, page, page, page)
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	var ids []string
+	err := client.EachInstance(func(i Instance) error {
+		ids = append(ids, i.ID)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if fmt.Sprint(ids) != "[1-1 1-2 2-1 2-2]" {
+		t.Errorf("Expected the instances from both pages, got %v", ids)
+	}
+
+	pagesFetched, ids = nil, nil
+	err = client.EachInstance(func(i Instance) error {
+		ids = append(ids, i.ID)
+		if len(ids) == 1 {
+			return StopIterationError
+		}
+		return nil
+	})
+	if err != nil || len(ids) != 1 || len(pagesFetched) != 1 {
+		t.Errorf("Expected to stop after the first instance, got %v, %v and pages %v", err, ids, pagesFetched)
+	}
+
+	failed := errors.New("failed")
+	err = client.EachInstance(func(i Instance) error { return failed })
+	if !errors.Is(err, failed) {
+		t.Errorf("Expected %v, got %v", failed, err)
+	}
+}
+
+func TestEachVolume(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/volumes": `[{"id": "12345", "name": "my-volume"}, {"id": "67890", "name": "other-volume"}]`,
+	})
+	defer server.Close()
+
+	var names []string
+	err := client.EachVolume(func(v Volume) error {
+		names = append(names, v.Name)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if fmt.Sprint(names) != "[my-volume other-volume]" {
+		t.Errorf("Expected both volumes, got %v", names)
+	}
+}
+
+func TestEachDiskImage(t *testing.T) {
+	var pagesFetched []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		page := req.URL.Query().Get("page")
+		pagesFetched = append(pagesFetched, page+"/"+req.URL.Query().Get("per_page"))
+		switch page {
+		case "1":
+			fmt.Fprint(rw, `{"page": 1, "per_page": 100, "pages": 3, "items": [{"id": "12345", "name": "ubuntu-jammy"}]}`)
+		case "2":
+			// filtered down to nothing, but not the last page
+			fmt.Fprint(rw, `{"page": 2, "per_page": 100, "pages": 3, "items": [{"id": "11111", "name": "k3s-ubuntu"}]}`)
+		default:
+			fmt.Fprint(rw, `{"page": 3, "per_page": 100, "pages": 3, "items": [{"id": "67890", "name": "debian-11"}]}`)
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	var names []string
+	err := client.EachDiskImage(func(d DiskImage) error {
+		names = append(names, d.Name)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if fmt.Sprint(names) != "[ubuntu-jammy debian-11]" {
+		t.Errorf("Expected the disk images from every page, got %v", names)
+	}
+	if fmt.Sprint(pagesFetched) != "[1/100 2/100 3/100]" {
+		t.Errorf("Expected each page to be fetched once, got %v", pagesFetched)
+	}
+
+	pagesFetched, names = nil, nil
+	err = client.EachDiskImage(func(d DiskImage) error {
+		names = append(names, d.Name)
+		return StopIterationError
+	})
+	if err != nil || fmt.Sprint(names) != "[ubuntu-jammy]" || len(pagesFetched) != 1 {
+		t.Errorf("Expected to stop after the first disk image, got %v, %v and pages %v", err, names, pagesFetched)
+	}
+}
@@ -1,11 +1,24 @@
 package civogo
 
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
 // Ping checks if Civo API is reachable and responding. Returns no error if API is reachable and running.
+// The request is authenticated, so an invalid or expired API key (a 401 or 403 response) returns an
+// AuthenticationError, letting tools check the key before starting any real work
 func (c *Client) Ping() error {
 	url := "/v2/ping"
 
 	_, err := c.SendGetRequest(url)
 	if err != nil {
+		var httpErr HTTPError
+		if errors.As(err, &httpErr) && (httpErr.Code == http.StatusUnauthorized || httpErr.Code == http.StatusForbidden) {
+			err := fmt.Errorf("the API key was rejected: %w", httpErr)
+			return AuthenticationError.wrap(err)
+		}
 		return decodeError(err)
 	}
 
@@ -0,0 +1,34 @@
+package civogo
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientPing(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(status)
+		rw.Write([]byte(`{"result":"success"}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	if err := client.Ping(); err != nil {
+		t.Errorf("Request returned an error: %s", err)
+	}
+
+	for _, status = range []int{http.StatusUnauthorized, http.StatusForbidden} {
+		err := client.Ping()
+		if !errors.Is(err, AuthenticationError) {
+			t.Errorf("Expected %v for a %d, got %v", AuthenticationError, status, err)
+		}
+	}
+
+	status = http.StatusInternalServerError
+	if err := client.Ping(); err == nil || errors.Is(err, AuthenticationError) {
+		t.Errorf("Expected a non-authentication error, got %v", err)
+	}
+}
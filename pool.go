@@ -2,9 +2,11 @@ package civogo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 )
@@ -119,6 +121,117 @@ func (c *Client) UpdateKubernetesClusterPool(cid, pid string, config *Kubernetes
 	return pool, nil
 }
 
+// AddKubernetesNodePool adds a node pool to the cluster without touching its other pools, and
+// returns the cluster with the new pool. pool is copied, so it isn't changed
+func (c *Client) AddKubernetesNodePool(clusterID string, pool KubernetesClusterPoolConfig) (*KubernetesCluster, error) {
+	if len(clusterID) == 0 {
+		err := fmt.Errorf("the cluster ID is empty")
+		return nil, IDisEmptyError.wrap(err)
+	}
+
+	if _, err := c.CreateKubernetesClusterPool(clusterID, &pool); err != nil {
+		return nil, err
+	}
+
+	return c.GetKubernetesCluster(clusterID)
+}
+
+// DeleteKubernetesNodePool removes a single node pool from the cluster, and returns the cluster
+// without it. A cluster needs at least one pool, so deleting the only one returns a
+// KubernetesClusterLastPoolError; delete the cluster instead
+func (c *Client) DeleteKubernetesNodePool(clusterID, poolID string) (*KubernetesCluster, error) {
+	if len(clusterID) == 0 || len(poolID) == 0 {
+		err := fmt.Errorf("the cluster or pool ID is empty")
+		return nil, IDisEmptyError.wrap(err)
+	}
+
+	pools, err := c.ListKubernetesClusterPools(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, pool := range pools {
+		if pool.ID == poolID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		err := fmt.Errorf("unable to find pool %s in cluster %s, zero matches", poolID, clusterID)
+		return nil, ZeroMatchesError.wrap(err)
+	}
+	if len(pools) == 1 {
+		err := fmt.Errorf("pool %s is the only pool in cluster %s", poolID, clusterID)
+		return nil, KubernetesClusterLastPoolError.wrap(err)
+	}
+
+	if _, err := c.DeleteKubernetesClusterPool(clusterID, poolID); err != nil {
+		return nil, err
+	}
+
+	return c.GetKubernetesCluster(clusterID)
+}
+
+// ScaleKubernetesNodePool sets the number of nodes in a pool. When wait is true it then waits,
+// up to timeout, until the pool has exactly count nodes and all of them are ACTIVE, so a scale
+// down only returns once the removed nodes are gone. It returns the cluster as last fetched.
+// pollInterval optionally overrides the default of 5 seconds between checks
+func (c *Client) ScaleKubernetesNodePool(clusterID, poolID string, count int, wait bool, timeout time.Duration, pollInterval ...time.Duration) (*KubernetesCluster, error) {
+	return c.ScaleKubernetesNodePoolWithContext(context.Background(), clusterID, poolID, count, wait, timeout, pollInterval...)
+}
+
+// ScaleKubernetesNodePoolWithContext is ScaleKubernetesNodePool bound to ctx
+func (c *Client) ScaleKubernetesNodePoolWithContext(ctx context.Context, clusterID, poolID string, count int, wait bool, timeout time.Duration, pollInterval ...time.Duration) (*KubernetesCluster, error) {
+	_, err := c.UpdateKubernetesClusterPool(clusterID, poolID, &KubernetesClusterPoolUpdateConfig{
+		Count:  &count,
+		Region: c.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !wait {
+		return c.GetKubernetesCluster(clusterID)
+	}
+
+	var cluster *KubernetesCluster
+	err = pollUntil(ctx, timeout, pollIntervalOrDefault(pollInterval), fmt.Sprintf("pool %s of cluster %s to have %d nodes", poolID, clusterID, count), func(ctx context.Context) (bool, error) {
+		var err error
+		cluster, err = c.GetKubernetesCluster(clusterID)
+		if err != nil {
+			return false, err
+		}
+
+		for _, pool := range cluster.Pools {
+			if pool.ID == poolID {
+				return isKubernetesPoolReady(pool, count), nil
+			}
+		}
+
+		err = fmt.Errorf("unable to find pool %s in cluster %s, zero matches", poolID, clusterID)
+		return false, ZeroMatchesError.wrap(err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cluster, nil
+}
+
+// isKubernetesPoolReady checks the pool has count nodes and they're all ACTIVE
+func isKubernetesPoolReady(pool KubernetesPool, count int) bool {
+	if len(pool.Instances) != count {
+		return false
+	}
+	for _, instance := range pool.Instances {
+		if instance.Status != InstanceStatusActive {
+			return false
+		}
+	}
+	return true
+}
+
 // DeleteKubernetesClusterPool delete a pool inside the cluster
 func (c *Client) DeleteKubernetesClusterPool(id, poolID string) (*SimpleResponse, error) {
 	resp, err := c.SendDeleteRequest(fmt.Sprintf("/v2/kubernetes/clusters/%s/pools/%s", id, poolID))
@@ -1,8 +1,14 @@
 package civogo
 
 import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 )
@@ -274,3 +280,117 @@ func TestDeleteKubernetesPoolInstance(t *testing.T) {
 		t.Errorf("Expected %+v, got %+v", expected, got)
 	}
 }
+
+func TestScaleKubernetesNodePool(t *testing.T) {
+	clusterResponses := []string{
+		`{"id":"cluster-1","pools":[{"id":"pool-1","count":3,"instances":[{"id":"a","status":"ACTIVE"},{"id":"b","status":"ACTIVE"},{"id":"c","status":"DELETING"}]}]}`,
+		`{"id":"cluster-1","pools":[{"id":"pool-1","count":2,"instances":[{"id":"a","status":"ACTIVE"},{"id":"b","status":"ACTIVE"}]}]}`,
+	}
+	gets := 0
+	var updateBody string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "PUT" {
+			buf := new(strings.Builder)
+			io.Copy(buf, req.Body)
+			updateBody = buf.String()
+			rw.Write([]byte(`{"id":"pool-1","count":2}`))
+			return
+		}
+		response := clusterResponses[len(clusterResponses)-1]
+		if gets < len(clusterResponses) {
+			response = clusterResponses[gets]
+		}
+		gets++
+		rw.Write([]byte(response))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.ScaleKubernetesNodePool("cluster-1", "pool-1", 2, true, time.Second, time.Millisecond)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(got.Pools[0].Instances) != 2 {
+		t.Errorf("Expected %d, got %d", 2, len(got.Pools[0].Instances))
+	}
+	if gets != 2 {
+		t.Errorf("Expected %d, got %d", 2, gets)
+	}
+	if updateBody != `{"count":2,"taints":null,"region":"TEST"}` {
+		t.Errorf("Expected %s, got %s", `{"count":2,"taints":null,"region":"TEST"}`, updateBody)
+	}
+}
+
+func TestAddKubernetesNodePool(t *testing.T) {
+	var createBody string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "POST" {
+			buf := new(strings.Builder)
+			io.Copy(buf, req.Body)
+			createBody = buf.String()
+			rw.Write([]byte(`{"result": "success"}`))
+			return
+		}
+		rw.Write([]byte(`{"id":"cluster-1","pools":[{"id":"pool-1","count":3},{"id":"pool-2","count":2,"size":"g4s.kube.small"}]}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	pool := KubernetesClusterPoolConfig{ID: "pool-2", Count: 2, Size: "g4s.kube.small"}
+	got, err := client.AddKubernetesNodePool("cluster-1", pool)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(got.Pools) != 2 || got.Pools[1].ID != "pool-2" {
+		t.Errorf("Expected the cluster with pool-2, got %+v", got.Pools)
+	}
+	expected := `{"region":"TEST","id":"pool-2","count":2,"size":"g4s.kube.small","taints":null}`
+	if createBody != expected {
+		t.Errorf("Expected %s, got %s", expected, createBody)
+	}
+	if pool.Region != "" {
+		t.Errorf("Expected the pool config not to be changed, got %+v", pool)
+	}
+}
+
+func TestDeleteKubernetesNodePool(t *testing.T) {
+	pools := `[{"id":"pool-1","count":3},{"id":"pool-2","count":2}]`
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "DELETE":
+			deleted = append(deleted, req.URL.Path)
+			rw.Write([]byte(`{"result": "success"}`))
+		case strings.HasSuffix(req.URL.Path, "/pools"):
+			rw.Write([]byte(pools))
+		default:
+			rw.Write([]byte(`{"id":"cluster-1","pools":[{"id":"pool-1","count":3}]}`))
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.DeleteKubernetesNodePool("cluster-1", "pool-2")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(got.Pools) != 1 || got.Pools[0].ID != "pool-1" {
+		t.Errorf("Expected the cluster without pool-2, got %+v", got.Pools)
+	}
+
+	if _, err := client.DeleteKubernetesNodePool("cluster-1", "pool-3"); !errors.Is(err, ZeroMatchesError) {
+		t.Errorf("Expected %v, got %v", ZeroMatchesError, err)
+	}
+
+	pools = `[{"id":"pool-1","count":3}]`
+	if _, err := client.DeleteKubernetesNodePool("cluster-1", "pool-1"); !errors.Is(err, KubernetesClusterLastPoolError) {
+		t.Errorf("Expected %v, got %v", KubernetesClusterLastPoolError, err)
+	}
+
+	if !reflect.DeepEqual(deleted, []string{"/v2/kubernetes/clusters/cluster-1/pools/pool-2"}) {
+		t.Errorf("Expected only pool-2 to be deleted, got %v", deleted)
+	}
+}
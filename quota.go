@@ -64,3 +64,92 @@ func (c *Client) GetQuota() (*Quota, error) {
 
 	return &quota, nil
 }
+
+// QuotaResourceHeadroom is how much of a single quota is left. A limit of 0 or less means the
+// quota is unlimited, in which case Remaining is -1
+type QuotaResourceHeadroom struct {
+	Limit     int  `json:"limit"`
+	Usage     int  `json:"usage"`
+	Remaining int  `json:"remaining"`
+	Unlimited bool `json:"unlimited"`
+	// WouldExceed is true when creating one more of the resource would go over the limit
+	WouldExceed bool `json:"would_exceed"`
+}
+
+// QuotaHeadroom is the remaining capacity for each of the account's quotas
+type QuotaHeadroom struct {
+	Instances         QuotaResourceHeadroom `json:"instances"`
+	CPUCores          QuotaResourceHeadroom `json:"cpu_cores"`
+	RAMMegabytes      QuotaResourceHeadroom `json:"ram_mb"`
+	DiskGigabytes     QuotaResourceHeadroom `json:"disk_gb"`
+	Volumes           QuotaResourceHeadroom `json:"volumes"`
+	Snapshots         QuotaResourceHeadroom `json:"snapshots"`
+	PublicIPAddresses QuotaResourceHeadroom `json:"public_ip_addresses"`
+	Subnets           QuotaResourceHeadroom `json:"subnets"`
+	Networks          QuotaResourceHeadroom `json:"networks"`
+	Firewalls         QuotaResourceHeadroom `json:"firewalls"`
+	FirewallRules     QuotaResourceHeadroom `json:"firewall_rules"`
+	Ports             QuotaResourceHeadroom `json:"ports"`
+	LoadBalancers     QuotaResourceHeadroom `json:"loadbalancers"`
+	ObjectStoreGB     QuotaResourceHeadroom `json:"objectstore_gb"`
+	Databases         QuotaResourceHeadroom `json:"databases"`
+	DatabaseSnapshots QuotaResourceHeadroom `json:"database_snapshots"`
+	DatabaseCPUCores  QuotaResourceHeadroom `json:"database_cpu_cores"`
+	DatabaseRAMMB     QuotaResourceHeadroom `json:"database_ram_mb"`
+	DatabaseDiskGB    QuotaResourceHeadroom `json:"database_disk_gb"`
+}
+
+// newQuotaResourceHeadroom works out the headroom left between usage and limit
+func newQuotaResourceHeadroom(limit, usage int) QuotaResourceHeadroom {
+	if limit <= 0 {
+		return QuotaResourceHeadroom{Limit: limit, Usage: usage, Remaining: -1, Unlimited: true}
+	}
+
+	remaining := limit - usage
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return QuotaResourceHeadroom{
+		Limit:       limit,
+		Usage:       usage,
+		Remaining:   remaining,
+		WouldExceed: remaining == 0,
+	}
+}
+
+// Headroom returns the remaining capacity for each of the quotas
+func (q *Quota) Headroom() *QuotaHeadroom {
+	return &QuotaHeadroom{
+		Instances:         newQuotaResourceHeadroom(q.InstanceCountLimit, q.InstanceCountUsage),
+		CPUCores:          newQuotaResourceHeadroom(q.CPUCoreLimit, q.CPUCoreUsage),
+		RAMMegabytes:      newQuotaResourceHeadroom(q.RAMMegabytesLimit, q.RAMMegabytesUsage),
+		DiskGigabytes:     newQuotaResourceHeadroom(q.DiskGigabytesLimit, q.DiskGigabytesUsage),
+		Volumes:           newQuotaResourceHeadroom(q.DiskVolumeCountLimit, q.DiskVolumeCountUsage),
+		Snapshots:         newQuotaResourceHeadroom(q.DiskSnapshotCountLimit, q.DiskSnapshotCountUsage),
+		PublicIPAddresses: newQuotaResourceHeadroom(q.PublicIPAddressLimit, q.PublicIPAddressUsage),
+		Subnets:           newQuotaResourceHeadroom(q.SubnetCountLimit, q.SubnetCountUsage),
+		Networks:          newQuotaResourceHeadroom(q.NetworkCountLimit, q.NetworkCountUsage),
+		Firewalls:         newQuotaResourceHeadroom(q.SecurityGroupLimit, q.SecurityGroupUsage),
+		FirewallRules:     newQuotaResourceHeadroom(q.SecurityGroupRuleLimit, q.SecurityGroupRuleUsage),
+		Ports:             newQuotaResourceHeadroom(q.PortCountLimit, q.PortCountUsage),
+		LoadBalancers:     newQuotaResourceHeadroom(q.LoadBalancerCountLimit, q.LoadBalancerCountUsage),
+		ObjectStoreGB:     newQuotaResourceHeadroom(q.ObjectStoreGigabytesLimit, q.ObjectStoreGigabytesUsage),
+		Databases:         newQuotaResourceHeadroom(q.DatabaseCountLimit, q.DatabaseCountUsage),
+		DatabaseSnapshots: newQuotaResourceHeadroom(q.DatabaseSnapshotCountLimit, q.DatabaseSnapshotCountUsage),
+		DatabaseCPUCores:  newQuotaResourceHeadroom(q.DatabaseCPUCoreLimit, q.DatabaseCPUCoreUsage),
+		DatabaseRAMMB:     newQuotaResourceHeadroom(q.DatabaseRAMMegabytesLimit, q.DatabaseRAMMegabytesUsage),
+		DatabaseDiskGB:    newQuotaResourceHeadroom(q.DatabaseDiskGigabytesLimit, q.DatabaseDiskGigabytesUsage),
+	}
+}
+
+// QuotaHeadroom returns how much of each quota the calling API account has left, so callers can
+// check there is room before creating resources
+func (c *Client) QuotaHeadroom() (*QuotaHeadroom, error) {
+	quota, err := c.GetQuota()
+	if err != nil {
+		return nil, err
+	}
+
+	return quota.Headroom(), nil
+}
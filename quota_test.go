@@ -181,3 +181,42 @@ func TestGetQuota(t *testing.T) {
 		t.Errorf("Expected %d, got %d", 0, got.DatabaseDiskGigabytesUsage)
 	}
 }
+
+func TestQuotaHeadroom(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/quota": `{
+			"instance_count_limit": 16,
+			"instance_count_usage": 6,
+			"disk_volume_count_limit": 4,
+			"disk_volume_count_usage": 4,
+			"network_count_limit": 10,
+			"network_count_usage": 12,
+			"loadbalancer_count_limit": -1,
+			"loadbalancer_count_usage": 3
+		}`,
+	})
+	defer server.Close()
+
+	got, err := client.QuotaHeadroom()
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+
+	tests := []struct {
+		name     string
+		got      QuotaResourceHeadroom
+		expected QuotaResourceHeadroom
+	}{
+		{"instances", got.Instances, QuotaResourceHeadroom{Limit: 16, Usage: 6, Remaining: 10}},
+		{"volumes", got.Volumes, QuotaResourceHeadroom{Limit: 4, Usage: 4, Remaining: 0, WouldExceed: true}},
+		{"networks", got.Networks, QuotaResourceHeadroom{Limit: 10, Usage: 12, Remaining: 0, WouldExceed: true}},
+		{"loadbalancers", got.LoadBalancers, QuotaResourceHeadroom{Limit: -1, Usage: 3, Remaining: -1, Unlimited: true}},
+		{"databases", got.Databases, QuotaResourceHeadroom{Remaining: -1, Unlimited: true}},
+	}
+	for _, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("Expected %s headroom %+v, got %+v", test.name, test.expected, test.got)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package civogo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestRateLimiter(t *testing.T) {
+	g := NewWithT(t)
+
+	limiter := newRateLimiter(100, 2)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		g.Expect(limiter.wait(context.Background())).To(Succeed())
+	}
+	// the first two requests use the burst, the next two wait ~10ms each
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 15*time.Millisecond))
+}
+
+func TestRateLimiterCancelled(t *testing.T) {
+	g := NewWithT(t)
+
+	limiter := newRateLimiter(0.001, 1)
+	g.Expect(limiter.wait(context.Background())).To(Succeed())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	g.Expect(errors.Is(limiter.wait(ctx), context.DeadlineExceeded)).To(BeTrue())
+}
+
+func TestSetRateLimit(t *testing.T) {
+	g := NewWithT(t)
+
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/ping": `{"result":"success"}`,
+	})
+	defer server.Close()
+
+	client.SetRateLimit(1000, 1)
+	g.Expect(client.Ping()).To(Succeed())
+	g.Expect(client.Ping()).To(Succeed())
+
+	client.SetRateLimit(0, 0)
+	g.Expect(client.rateLimiter).To(BeNil())
+}
@@ -126,6 +126,102 @@ func (c *Client) GetDefaultRegion() (*Region, error) {
 	return nil, errors.New("no default region found")
 }
 
+// regionFeatures maps the feature names RegionSupports accepts to the matching Feature flag
+var regionFeatures = map[string]func(Feature) bool{
+	"iaas":                 func(f Feature) bool { return f.Iaas },
+	"instances":            func(f Feature) bool { return f.Iaas },
+	"kubernetes":           func(f Feature) bool { return f.Kubernetes },
+	"objectstore":          func(f Feature) bool { return f.ObjectStore },
+	"object_store":         func(f Feature) bool { return f.ObjectStore },
+	"loadbalancer":         func(f Feature) bool { return f.LoadBalancer },
+	"gpu":                  func(f Feature) bool { return f.GPU },
+	"database":             func(f Feature) bool { return f.DBaaS },
+	"dbaas":                func(f Feature) bool { return f.DBaaS },
+	"volume":               func(f Feature) bool { return f.Volume },
+	"paas":                 func(f Feature) bool { return f.PaaS },
+	"kfaas":                func(f Feature) bool { return f.KFaaS },
+	"public_ip_node_pools": func(f Feature) bool { return f.PublicIPNodePools },
+}
+
+// getRegionByCode finds the region with exactly the code given, ignoring case
+func (c *Client) getRegionByCode(code string) (*Region, error) {
+	regions, err := c.ListRegions()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, region := range regions {
+		if strings.EqualFold(region.Code, code) {
+			return &regions[i], nil
+		}
+	}
+
+	err = fmt.Errorf("unable to find region %s, zero matches", code)
+	return nil, ZeroMatchesError.wrap(err)
+}
+
+// IsRegionAvailable reports whether new resources can be created in the region with the given
+// code. When the region is unavailable it returns false with a RegionUnavailableError giving the
+// reason, so errors.Is tells that apart from a failure to look the region up
+func (c *Client) IsRegionAvailable(region string) (bool, error) {
+	r, err := c.getRegionByCode(region)
+	if err != nil {
+		return false, err
+	}
+
+	if r.OutOfCapacity {
+		err := fmt.Errorf("region %s is out of capacity", r.Code)
+		return false, RegionUnavailableError.wrap(err)
+	}
+
+	return true, nil
+}
+
+// RegionSupports reports whether the region with the given code offers feature, one of "iaas"
+// (or "instances"), "kubernetes", "objectstore", "loadbalancer", "gpu", "database" (or "dbaas"),
+// "volume", "paas", "kfaas" or "public_ip_node_pools". Any other feature returns a
+// RegionUnknownFeatureError
+func (c *Client) RegionSupports(region, feature string) (bool, error) {
+	supports, ok := regionFeatures[strings.ToLower(feature)]
+	if !ok {
+		err := fmt.Errorf("unknown region feature %s", feature)
+		return false, RegionUnknownFeatureError.wrap(err)
+	}
+
+	r, err := c.getRegionByCode(region)
+	if err != nil {
+		return false, err
+	}
+
+	return supports(r.Features), nil
+}
+
+// FindRegionWithFeature returns the regions that offer feature, using the same feature names as
+// RegionSupports (e.g. "database" or "gpu"), so a workload can be placed in a region that can
+// run it. Regions that are out of capacity are left out. An unknown feature returns a
+// RegionUnknownFeatureError
+func (c *Client) FindRegionWithFeature(feature string) ([]Region, error) {
+	supports, ok := regionFeatures[strings.ToLower(feature)]
+	if !ok {
+		err := fmt.Errorf("unknown region feature %s", feature)
+		return nil, RegionUnknownFeatureError.wrap(err)
+	}
+
+	regions, err := c.ListRegions()
+	if err != nil {
+		return nil, err
+	}
+
+	matching := make([]Region, 0)
+	for _, region := range regions {
+		if supports(region.Features) && !region.OutOfCapacity {
+			matching = append(matching, region)
+		}
+	}
+
+	return matching, nil
+}
+
 // CreateRegion is a function to create a region
 func (c *Client) CreateRegion(r *CreateRegionRequest) (*Region, error) {
 	resp, err := c.SendPostRequest("/v2/regions", r)
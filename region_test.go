@@ -1,6 +1,7 @@
 package civogo
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -42,6 +43,93 @@ func TestFindRegions(t *testing.T) {
 	}
 }
 
+func TestIsRegionAvailable(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/regions": `[{"code":"NYC1","name":"New York 1","type":"civostack","out_of_capacity":false,"country":"us","country_name":"United States","features":{"iaas":false,"kubernetes":true}},{"code":"SVG1","name":"Stevenage 1","default":true,"type":"openstack","out_of_capacity":true,"country":"uk","country_name":"United Kingdom","features":{"iaas":true,"kubernetes":true}}]`,
+	})
+	defer server.Close()
+
+	got, err := client.IsRegionAvailable("nyc1")
+	if err != nil || !got {
+		t.Errorf("Expected NYC1 to be available, got %t, %v", got, err)
+	}
+
+	got, err = client.IsRegionAvailable("SVG1")
+	if got || !errors.Is(err, RegionUnavailableError) {
+		t.Errorf("Expected %v, got %t, %v", RegionUnavailableError, got, err)
+	}
+
+	_, err = client.IsRegionAvailable("FRA1")
+	if !errors.Is(err, ZeroMatchesError) {
+		t.Errorf("Expected %v, got %v", ZeroMatchesError, err)
+	}
+}
+
+func TestRegionSupports(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/regions": `[{"code":"NYC1","name":"New York 1","type":"civostack","out_of_capacity":false,"country":"us","country_name":"United States","features":{"iaas":false,"kubernetes":true,"dbaas":true}}]`,
+	})
+	defer server.Close()
+
+	tests := []struct {
+		feature  string
+		expected bool
+	}{
+		{"kubernetes", true},
+		{"database", true},
+		{"iaas", false},
+		{"objectstore", false},
+	}
+	for _, test := range tests {
+		got, err := client.RegionSupports("NYC1", test.feature)
+		if err != nil {
+			t.Errorf("Request returned an error: %s", err)
+			continue
+		}
+		if got != test.expected {
+			t.Errorf("Expected %s to be %t, got %t", test.feature, test.expected, got)
+		}
+	}
+
+	_, err := client.RegionSupports("NYC1", "teleport")
+	if !errors.Is(err, RegionUnknownFeatureError) {
+		t.Errorf("Expected %v, got %v", RegionUnknownFeatureError, err)
+	}
+}
+
+func TestFindRegionWithFeature(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/regions": `[
+			{"code":"NYC1","name":"New York 1","out_of_capacity":false,"features":{"kubernetes":true,"dbaas":true}},
+			{"code":"LON1","name":"London 1","out_of_capacity":false,"features":{"kubernetes":true,"dbaas":false}},
+			{"code":"FRA1","name":"Frankfurt 1","out_of_capacity":true,"features":{"kubernetes":true,"dbaas":true}}
+		]`,
+	})
+	defer server.Close()
+
+	got, err := client.FindRegionWithFeature("database")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(got) != 1 || got[0].Code != "NYC1" {
+		t.Errorf("Expected only NYC1, got %+v", got)
+	}
+
+	got, err = client.FindRegionWithFeature("Kubernetes")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(got) != 2 || got[0].Code != "NYC1" || got[1].Code != "LON1" {
+		t.Errorf("Expected NYC1 and LON1, got %+v", got)
+	}
+
+	if _, err := client.FindRegionWithFeature("teleport"); !errors.Is(err, RegionUnknownFeatureError) {
+		t.Errorf("Expected %v, got %v", RegionUnknownFeatureError, err)
+	}
+}
+
 func TestCreateRegion(t *testing.T) {
 	client, server, _ := NewClientForTesting(map[string]string{
 		"/v2/regions": `{
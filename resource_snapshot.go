@@ -2,9 +2,13 @@ package civogo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/civo/civogo/utils"
 )
 
 // ResourceSnapshot represents a snapshot of any resource type
@@ -56,6 +60,18 @@ type RestoreResourceSnapshotRequest struct {
 	Instance *RestoreInstanceSnapshotRequest `json:"instance,omitempty"`
 }
 
+// RestoreParams are the overrides for an instance restored from a snapshot by
+// RestoreSnapshotToInstance. The restore API always puts the new instance on the snapshotted
+// instance's network, so the network can't be overridden; move it afterwards if needed
+type RestoreParams struct {
+	Hostname    string
+	Description string
+	PrivateIPv4 string
+	// Size, if set, resizes the new instance to this size once it is ACTIVE
+	Size           string
+	IncludeVolumes bool
+}
+
 // ListResourceSnapshots returns all resource snapshots
 func (c *Client) ListResourceSnapshots() ([]ResourceSnapshot, error) {
 	resp, err := c.SendGetRequest("/v2/resourcesnapshots")
@@ -125,3 +141,109 @@ func (c *Client) RestoreResourceSnapshot(id string, req *RestoreResourceSnapshot
 
 	return &snapshot, nil
 }
+
+// RestoreSnapshotToInstance restores an instance snapshot as a new instance, leaving the
+// snapshotted instance untouched, and returns the new instance so callers can poll it until it
+// is ACTIVE. The snapshot must have finished (its state "available" or "completed"). A random
+// hostname is used if params doesn't give one. The restore API doesn't return the new
+// instance, so it's looked for by hostname, ignoring instances that already had that hostname,
+// for up to timeout until it appears. If params.Size is set, the new instance is also waited for
+// (again for up to timeout) until it's ACTIVE and then resized. pollInterval optionally overrides
+// the default of 5 seconds between checks
+func (c *Client) RestoreSnapshotToInstance(snapshotID string, params RestoreParams, timeout time.Duration, pollInterval ...time.Duration) (*Instance, error) {
+	return c.RestoreSnapshotToInstanceWithContext(context.Background(), snapshotID, params, timeout, pollInterval...)
+}
+
+// RestoreSnapshotToInstanceWithContext is RestoreSnapshotToInstance bound to ctx
+func (c *Client) RestoreSnapshotToInstanceWithContext(ctx context.Context, snapshotID string, params RestoreParams, timeout time.Duration, pollInterval ...time.Duration) (*Instance, error) {
+	snapshot, err := c.GetResourceSnapshot(snapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot.ResourceType != "instance" || snapshot.Instance == nil {
+		err := fmt.Errorf("snapshot %s is a %s snapshot, not an instance one", snapshotID, snapshot.ResourceType)
+		return nil, ResourceSnapshotNotInstanceError.wrap(err)
+	}
+
+	state := snapshot.Instance.Status.State
+	if !strings.EqualFold(state, "available") && !strings.EqualFold(state, "completed") {
+		err := fmt.Errorf("snapshot %s is %s, it must be completed before it can be restored", snapshotID, state)
+		return nil, ResourceSnapshotNotCompletedError.wrap(err)
+	}
+
+	if params.Hostname == "" {
+		params.Hostname = utils.RandomName()
+	}
+
+	existing, err := c.instanceIDsWithHostname(params.Hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.RestoreResourceSnapshot(snapshotID, &RestoreResourceSnapshotRequest{
+		Instance: &RestoreInstanceSnapshotRequest{
+			Description:    params.Description,
+			Hostname:       params.Hostname,
+			PrivateIPv4:    params.PrivateIPv4,
+			IncludeVolumes: params.IncludeVolumes,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var restored *Instance
+	err = pollUntil(ctx, timeout, pollIntervalOrDefault(pollInterval), fmt.Sprintf("instance %s restored from snapshot %s to appear", params.Hostname, snapshotID), func(ctx context.Context) (bool, error) {
+		instances, err := c.ListAllInstances()
+		if err != nil {
+			return false, err
+		}
+
+		var found []Instance
+		for _, instance := range instances {
+			if instance.Hostname == params.Hostname && !existing[instance.ID] {
+				found = append(found, instance)
+			}
+		}
+		if len(found) > 1 {
+			err := fmt.Errorf("snapshot %s was restored but several new instances are named %s", snapshotID, params.Hostname)
+			return false, MultipleMatchesError.wrap(err)
+		}
+		if len(found) == 1 {
+			restored = &found[0]
+		}
+		return restored != nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Size == "" || params.Size == restored.Size {
+		return restored, nil
+	}
+
+	if _, err := c.WaitForInstanceStatusWithContext(ctx, restored.ID, InstanceStatusActive, timeout, pollInterval...); err != nil {
+		return restored, err
+	}
+	if _, err := c.ResizeInstance(restored.ID, params.Size); err != nil {
+		return restored, err
+	}
+	return c.GetInstanceWithContext(ctx, restored.ID)
+}
+
+// instanceIDsWithHostname returns the IDs of the instances named hostname
+func (c *Client) instanceIDsWithHostname(hostname string) (map[string]bool, error) {
+	instances, err := c.ListAllInstances()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := map[string]bool{}
+	for _, instance := range instances {
+		if instance.Hostname == hostname {
+			ids[instance.ID] = true
+		}
+	}
+	return ids, nil
+}
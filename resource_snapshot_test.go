@@ -1,7 +1,12 @@
 package civogo
 
 import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 )
@@ -149,3 +154,87 @@ func TestRestoreResourceSnapshot(t *testing.T) {
 		t.Errorf("Expected name 'restored-snapshot', got %s", got.Name)
 	}
 }
+
+func TestRestoreSnapshotToInstance(t *testing.T) {
+	var mu sync.Mutex
+	var restore RestoreResourceSnapshotRequest
+	var resized map[string]string
+	listsAfterRestore := -1
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch req.URL.Path {
+		case "/v2/resourcesnapshots/12345":
+			rw.Write([]byte(`{"id": "12345", "resource_type": "instance", "instance": {"id": "inst-12345", "status": {"state": "available"}}}`))
+		case "/v2/resourcesnapshots/12345/restore":
+			json.NewDecoder(req.Body).Decode(&restore)
+			listsAfterRestore = 0
+			rw.Write([]byte(`{"id": "12345", "resource_type": "instance"}`))
+		case "/v2/instances":
+			// an older instance already has the hostname, and the restored one takes a while to show up
+			items := `{"id": "inst-12345", "hostname": "web"}, {"id": "inst-old", "hostname": "web-restored"}`
+			if listsAfterRestore >= 0 {
+				listsAfterRestore++
+			}
+			if listsAfterRestore > 2 {
+				items += `, {"id": "inst-67890", "hostname": "web-restored", "size": "g3.small", "status": "BUILDING"}`
+			}
+			rw.Write([]byte(`{"page": 1, "per_page": 20, "pages": 1, "items": [` + items + `]}`))
+		case "/v2/instances/inst-67890":
+			size := "g3.small"
+			if resized != nil {
+				size = resized["size"]
+			}
+			rw.Write([]byte(`{"id": "inst-67890", "hostname": "web-restored", "size": "` + size + `", "status": "ACTIVE"}`))
+		case "/v2/instances/inst-67890/resize":
+			json.NewDecoder(req.Body).Decode(&resized)
+			rw.Write([]byte(`{"result": "success"}`))
+		case "/v2/sizes":
+			rw.Write([]byte(`[{"name": "g3.small", "cpu_cores": 1, "ram_mb": 2048, "disk_gb": 25}, {"name": "g3.medium", "cpu_cores": 2, "ram_mb": 4096, "disk_gb": 50}]`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.RestoreSnapshotToInstance("12345", RestoreParams{Hostname: "web-restored", IncludeVolumes: true}, time.Second, time.Millisecond)
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+		return
+	}
+
+	if got.ID != "inst-67890" {
+		t.Errorf("Expected the new instance inst-67890, got %+v", got)
+	}
+	if restore.Instance == nil || restore.Instance.Hostname != "web-restored" || !restore.Instance.IncludeVolumes || restore.Instance.OverwriteExisting {
+		t.Errorf("Expected a restore to a new instance named web-restored, got %+v", restore.Instance)
+	}
+	if resized != nil {
+		t.Errorf("Expected no resize without a size override, got %v", resized)
+	}
+
+	mu.Lock()
+	listsAfterRestore = -1
+	mu.Unlock()
+	got, err = client.RestoreSnapshotToInstance("12345", RestoreParams{Hostname: "web-restored", Size: "g3.medium"}, time.Second, time.Millisecond)
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+		return
+	}
+	if got.ID != "inst-67890" || got.Size != "g3.medium" {
+		t.Errorf("Expected inst-67890 resized to g3.medium, got %+v", got)
+	}
+}
+
+func TestRestoreSnapshotToInstanceNotCompleted(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/resourcesnapshots/12345": `{"id": "12345", "resource_type": "instance", "instance": {"id": "inst-12345", "status": {"state": "in_progress"}}}`,
+	})
+	defer server.Close()
+
+	_, err := client.RestoreSnapshotToInstance("12345", RestoreParams{Hostname: "web-restored"}, time.Second, time.Millisecond)
+	if !errors.Is(err, ResourceSnapshotNotCompletedError) {
+		t.Errorf("Expected %v, got %v", ResourceSnapshotNotCompletedError, err)
+	}
+}
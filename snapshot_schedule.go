@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -69,8 +70,18 @@ type UpdateSnapshotScheduleRequest struct {
 	Paused      bool   `json:"paused,omitempty"`
 }
 
-// CreateSnapshotSchedule creates a new snapshot schedule
+// CreateSnapshotSchedule creates a new snapshot schedule. The cron expression is checked before
+// the request is sent, and the schedule must keep at least 1 snapshot (Retention.MaxSnapshots)
 func (c *Client) CreateSnapshotSchedule(r *CreateSnapshotScheduleRequest) (*SnapshotSchedule, error) {
+	if r.Retention.MaxSnapshots < 1 {
+		err := fmt.Errorf("the schedule must keep at least 1 snapshot, got %d", r.Retention.MaxSnapshots)
+		return nil, SnapshotScheduleInvalidRetentionError.wrap(err)
+	}
+
+	if err := validateCronExpression(r.CronExpression); err != nil {
+		return nil, SnapshotScheduleInvalidCronError.wrap(err)
+	}
+
 	body, err := c.SendPostRequest("/v2/resourcesnapshotschedules", r)
 	if err != nil {
 		return nil, decodeError(err)
@@ -172,3 +183,101 @@ func (c *Client) UpdateSnapshotSchedule(id string, r *UpdateSnapshotScheduleRequ
 
 	return schedule, nil
 }
+
+// cronMacros are the shorthand schedules accepted in place of the five cron fields
+var cronMacros = map[string]bool{
+	"@yearly": true, "@annually": true, "@monthly": true, "@weekly": true,
+	"@daily": true, "@midnight": true, "@hourly": true,
+}
+
+// cronField describes the values one of the five cron fields accepts
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// validateCronExpression checks expression is a standard five field cron expression (or one of
+// the @daily style macros), supporting *, ranges, steps, lists and month and day names
+func validateCronExpression(expression string) error {
+	expression = strings.TrimSpace(expression)
+	if cronMacros[strings.ToLower(expression)] {
+		return nil
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("cron expression %q must have %d fields, got %d", expression, len(cronFields), len(fields))
+	}
+
+	for i, field := range fields {
+		for _, item := range strings.Split(field, ",") {
+			if err := cronFields[i].validate(item); err != nil {
+				return fmt.Errorf("cron expression %q has an invalid %s: %w", expression, cronFields[i].name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validate checks a single item of a cron field list, e.g. "*", "5", "1-5", "*/15" or "MON-FRI"
+func (f cronField) validate(item string) error {
+	rangePart, step, hasStep := strings.Cut(item, "/")
+	if hasStep {
+		n, err := strconv.Atoi(step)
+		if err != nil || n < 1 {
+			return fmt.Errorf("%q is not a valid step", step)
+		}
+	}
+
+	if rangePart == "*" {
+		return nil
+	}
+
+	low, high, isRange := strings.Cut(rangePart, "-")
+	start, err := f.value(low)
+	if err != nil {
+		return err
+	}
+	if !isRange {
+		return nil
+	}
+
+	end, err := f.value(high)
+	if err != nil {
+		return err
+	}
+	if end < start {
+		return fmt.Errorf("range %q ends before it starts", rangePart)
+	}
+
+	return nil
+}
+
+// value parses a single value of the field, either a number or one of its names
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			if f.min == 1 {
+				return i + 1, nil
+			}
+			return i, nil
+		}
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("%q is not between %d and %d", s, f.min, f.max)
+	}
+
+	return n, nil
+}
@@ -1,6 +1,7 @@
 package civogo
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -159,3 +160,42 @@ func TestDeleteSnapshotSchedule(t *testing.T) {
 		t.Errorf("Expected result success, got %s", got.Result)
 	}
 }
+
+func TestCreateSnapshotScheduleValidation(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/resourcesnapshotschedules": `{"id": "schedule-123"}`,
+	})
+	defer server.Close()
+
+	tests := []struct {
+		cron      string
+		retention int
+		expected  error
+	}{
+		{"0 0 * * *", 7, nil},
+		{"*/15 9-17 * JAN-JUN mon-fri", 1, nil},
+		{"0 3 1,15 * 0", 3, nil},
+		{"@daily", 1, nil},
+		{"0 0 * * *", 0, SnapshotScheduleInvalidRetentionError},
+		{"0 0 * *", 7, SnapshotScheduleInvalidCronError},
+		{"60 0 * * *", 7, SnapshotScheduleInvalidCronError},
+		{"0 0 * * 8", 7, SnapshotScheduleInvalidCronError},
+		{"0 17-9 * * *", 7, SnapshotScheduleInvalidCronError},
+		{"*/0 * * * *", 7, SnapshotScheduleInvalidCronError},
+		{"@sometimes", 7, SnapshotScheduleInvalidCronError},
+	}
+
+	for _, test := range tests {
+		_, err := client.CreateSnapshotSchedule(&CreateSnapshotScheduleRequest{
+			Name:           "daily-schedule",
+			CronExpression: test.cron,
+			Retention:      SnapshotRetention{MaxSnapshots: test.retention},
+		})
+		if test.expected == nil && err != nil {
+			t.Errorf("Expected %q keeping %d to be valid, got %v", test.cron, test.retention, err)
+		}
+		if test.expected != nil && !errors.Is(err, test.expected) {
+			t.Errorf("Expected %v for %q keeping %d, got %v", test.expected, test.cron, test.retention, err)
+		}
+	}
+}
@@ -2,7 +2,12 @@ package civogo
 
 import (
 	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -45,6 +50,25 @@ func (c *Client) NewSSHKey(name string, publicKey string) (*SimpleResponse, erro
 	return c.DecodeSimpleResponse(resp)
 }
 
+// NewSSHKeyIfNotExists creates a new SSH key record, unless the account already has a key with
+// the same fingerprint, in which case the response carries the existing key's ID instead
+func (c *Client) NewSSHKeyIfNotExists(name string, publicKey string) (*SimpleResponse, error) {
+	fingerprint, err := ComputeSSHKeyFingerprint(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := c.FindSSHKeyByFingerprint(fingerprint)
+	if err == nil {
+		return &SimpleResponse{ID: existing.ID, Result: ResultSuccess}, nil
+	}
+	if !errors.Is(err, ZeroMatchesError) {
+		return nil, err
+	}
+
+	return c.NewSSHKey(name, publicKey)
+}
+
 // UpdateSSHKey update a SSH key record
 func (c *Client) UpdateSSHKey(name string, sshKeyID string) (*SSHKey, error) {
 	resp, err := c.SendPutRequest(fmt.Sprintf("/v2/sshkeys/%s", sshKeyID), map[string]string{
@@ -96,6 +120,95 @@ func (c *Client) FindSSHKey(search string) (*SSHKey, error) {
 	}
 }
 
+// FindSSHKeyByFingerprint finds an SSH key by its fingerprint, given either in the SHA256 format
+// ("SHA256:...") or the MD5 format ("aa:bb:...", optionally prefixed "MD5:")
+func (c *Client) FindSSHKeyByFingerprint(fingerprint string) (*SSHKey, error) {
+	want := normaliseSSHKeyFingerprint(fingerprint)
+	if want == "" {
+		err := fmt.Errorf("the fingerprint is empty")
+		return nil, SSHKeyInvalidError.wrap(err)
+	}
+
+	keys, err := c.ListSSHKeys()
+	if err != nil {
+		return nil, decodeError(err)
+	}
+
+	for _, key := range keys {
+		if key.Fingerprint != "" && normaliseSSHKeyFingerprint(key.Fingerprint) == want {
+			return &key, nil
+		}
+
+		// the stored fingerprint may be in the other format, so work both out from the key itself
+		sha, err := ComputeSSHKeyFingerprint(key.PublicKey)
+		if err != nil {
+			continue
+		}
+		md5Fingerprint, _ := ComputeSSHKeyMD5Fingerprint(key.PublicKey)
+		if normaliseSSHKeyFingerprint(sha) == want || normaliseSSHKeyFingerprint(md5Fingerprint) == want {
+			return &key, nil
+		}
+	}
+
+	err = fmt.Errorf("unable to find an SSH key with fingerprint %s, zero matches", fingerprint)
+	return nil, ZeroMatchesError.wrap(err)
+}
+
+// ComputeSSHKeyFingerprint returns the SHA256 fingerprint of an authorized_keys style public key
+// ("ssh-ed25519 AAAA... comment"), in the "SHA256:..." format ssh-keygen -l shows
+func ComputeSSHKeyFingerprint(publicKey string) (string, error) {
+	blob, err := sshPublicKeyBlob(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(blob)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:]), nil
+}
+
+// ComputeSSHKeyMD5Fingerprint returns the MD5 fingerprint of an authorized_keys style public key,
+// in the colon separated hex format ("aa:bb:...") older tools show
+func ComputeSSHKeyMD5Fingerprint(publicKey string) (string, error) {
+	blob, err := sshPublicKeyBlob(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	sum := md5.Sum(blob)
+	pairs := make([]string, len(sum))
+	for i, b := range sum {
+		pairs[i] = hex.EncodeToString([]byte{b})
+	}
+	return strings.Join(pairs, ":"), nil
+}
+
+// sshPublicKeyBlob decodes the base64 key data from an authorized_keys style public key
+func sshPublicKeyBlob(publicKey string) ([]byte, error) {
+	fields := strings.Fields(publicKey)
+	if len(fields) < 2 {
+		err := fmt.Errorf("the public key should be in the form \"<type> <base64 data> [comment]\"")
+		return nil, SSHKeyInvalidError.wrap(err)
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		err := fmt.Errorf("the public key data isn't valid base64: %w", err)
+		return nil, SSHKeyInvalidError.wrap(err)
+	}
+
+	return blob, nil
+}
+
+// normaliseSSHKeyFingerprint lets fingerprints be compared regardless of an "MD5:" prefix or the
+// case of MD5 hex digits. SHA256 fingerprints are base64 so keep their case
+func normaliseSSHKeyFingerprint(fingerprint string) string {
+	fingerprint = strings.TrimSpace(fingerprint)
+	if strings.HasPrefix(fingerprint, "SHA256:") {
+		return fingerprint
+	}
+	return strings.ToLower(strings.TrimPrefix(fingerprint, "MD5:"))
+}
+
 // DeleteSSHKey deletes an SSH key
 func (c *Client) DeleteSSHKey(id string) (*SimpleResponse, error) {
 	resp, err := c.SendDeleteRequest(fmt.Sprintf("/v2/sshkeys/%s", id))
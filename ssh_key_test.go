@@ -1,6 +1,9 @@
 package civogo
 
 import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 )
@@ -74,3 +77,69 @@ func TestFindSSHKey(t *testing.T) {
 		t.Errorf("Expected %s, got %s", "unable to find missing, zero matches", err.Error())
 	}
 }
+
+const testSSHPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKij/v0OD3tv8Sv8Myg0N1pkCWWNs4N4XCCZdG6p9zl6 test"
+
+func TestComputeSSHKeyFingerprint(t *testing.T) {
+	got, err := ComputeSSHKeyFingerprint(testSSHPublicKey)
+	if err != nil || got != "SHA256:t0QvY3Jts6Si1mxgpxjY2D9CBrlszHsjSXyoyIbTGqk" {
+		t.Errorf("Expected %s, got %s (%v)", "SHA256:t0QvY3Jts6Si1mxgpxjY2D9CBrlszHsjSXyoyIbTGqk", got, err)
+	}
+
+	got, err = ComputeSSHKeyMD5Fingerprint(testSSHPublicKey)
+	if err != nil || got != "7f:b5:de:3c:76:39:8b:9f:f3:a4:60:84:1e:ca:1a:12" {
+		t.Errorf("Expected %s, got %s (%v)", "7f:b5:de:3c:76:39:8b:9f:f3:a4:60:84:1e:ca:1a:12", got, err)
+	}
+
+	if _, err := ComputeSSHKeyFingerprint("not-a-key"); !errors.Is(err, SSHKeyInvalidError) {
+		t.Errorf("Expected %v, got %v", SSHKeyInvalidError, err)
+	}
+}
+
+func TestFindSSHKeyByFingerprint(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/sshkeys": `[
+			{"id": "12345", "name": "other", "fingerprint": "00:11:22:33:44:55:66:77:88:99:aa:bb:cc:dd:ee:ff", "public_key": ""},
+			{"id": "67890", "name": "test", "fingerprint": "7f:b5:de:3c:76:39:8b:9f:f3:a4:60:84:1e:ca:1a:12", "public_key": "` + testSSHPublicKey + `"}
+		]`,
+	})
+	defer server.Close()
+
+	for _, fingerprint := range []string{"MD5:7F:B5:DE:3C:76:39:8B:9F:F3:A4:60:84:1E:CA:1A:12", "SHA256:t0QvY3Jts6Si1mxgpxjY2D9CBrlszHsjSXyoyIbTGqk"} {
+		got, err := client.FindSSHKeyByFingerprint(fingerprint)
+		if err != nil {
+			t.Errorf("Request returned an error: %s", err)
+			continue
+		}
+		if got.ID != "67890" {
+			t.Errorf("Expected %s for %s, got %s", "67890", fingerprint, got.ID)
+		}
+	}
+
+	if _, err := client.FindSSHKeyByFingerprint("SHA256:missing"); !errors.Is(err, ZeroMatchesError) {
+		t.Errorf("Expected %v, got %v", ZeroMatchesError, err)
+	}
+}
+
+func TestNewSSHKeyIfNotExists(t *testing.T) {
+	created := false
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == "POST" {
+			created = true
+			rw.Write([]byte(`{"result": "success", "id": "new"}`))
+			return
+		}
+		rw.Write([]byte(`[{"id": "67890", "name": "test", "public_key": "` + testSSHPublicKey + `"}]`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.NewSSHKeyIfNotExists("again", testSSHPublicKey+" renamed")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if created || got.ID != "67890" {
+		t.Errorf("Expected the existing key 67890 to be returned, got %+v (created: %t)", got, created)
+	}
+}
@@ -144,6 +144,34 @@ func (c *Client) AddTeamMember(teamID, userID, permissions, roles string) ([]Tea
 	return c.ListTeamMembers(teamID)
 }
 
+// AssignTeamMember adds a user to the specified team with the given permission codes. Each
+// permission is checked against ListPermissions first, so a typo returns a
+// TeamPermissionInvalidError (listing the unknown codes) rather than a member without access
+func (c *Client) AssignTeamMember(teamID, userID string, permissions []string) ([]TeamMember, error) {
+	known, err := c.ListPermissions()
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make(map[string]bool, len(known))
+	for _, permission := range known {
+		codes[permission.Code] = true
+	}
+
+	unknown := []string{}
+	for _, permission := range permissions {
+		if !codes[permission] {
+			unknown = append(unknown, permission)
+		}
+	}
+	if len(unknown) > 0 {
+		err := fmt.Errorf("unknown permissions %s", strings.Join(unknown, ", "))
+		return nil, TeamPermissionInvalidError.wrap(err)
+	}
+
+	return c.AddTeamMember(teamID, userID, strings.Join(permissions, ","), "")
+}
+
 // UpdateTeamMember changes the permissions or roles for a specified team member
 func (c *Client) UpdateTeamMember(teamID, teamMemberID, permissions, roles string) (*TeamMember, error) {
 	data := map[string]string{"permissions": permissions, "roles": roles}
@@ -1,6 +1,7 @@
 package civogo
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -112,6 +113,49 @@ func TestAddTeamMember(t *testing.T) {
 	}
 }
 
+func TestAssignTeamMember(t *testing.T) {
+	client, server, _ := NewAdvancedClientForTesting([]ConfigAdvanceClientForTesting{
+		{
+			Method: "GET",
+			Value: []ValueAdvanceClientForTesting{
+				{
+					URL:          "/v2/permissions",
+					ResponseBody: `[{"code":"instance.create","name":"Create instances"},{"code":"kubernetes.*","name":"Manage Kubernetes"}]`,
+				},
+				{
+					URL:          "/v2/teams/12345/members",
+					ResponseBody: `[{"id":"abcde","user_id":"bcdef","permissions":"instance.create,kubernetes.*"}]`,
+				},
+			},
+		},
+		{
+			Method: "POST",
+			Value: []ValueAdvanceClientForTesting{
+				{
+					URL:          "/v2/teams/12345/members",
+					RequestBody:  `{"permissions":"instance.create,kubernetes.*","roles":"","user_id":"bcdef"}`,
+					ResponseBody: `{"id":"abcde","user_id":"bcdef"}`,
+				},
+			},
+		},
+	})
+	defer server.Close()
+
+	got, err := client.AssignTeamMember("12345", "bcdef", []string{"instance.create", "kubernetes.*"})
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got[0].Permissions != "instance.create,kubernetes.*" {
+		t.Errorf("Expected %s, got %s", "instance.create,kubernetes.*", got[0].Permissions)
+	}
+
+	_, err = client.AssignTeamMember("12345", "bcdef", []string{"instance.create", "instance.destroy"})
+	if !errors.Is(err, TeamPermissionInvalidError) {
+		t.Errorf("Expected %v, got %v", TeamPermissionInvalidError, err)
+	}
+}
+
 func TestUpdateTeamMember(t *testing.T) {
 	client, server, _ := NewClientForTesting(map[string]string{
 		"/v2/teams/12345/members/abcde": `{"id":"12345","permissions":"*.*"}`,
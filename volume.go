@@ -2,8 +2,11 @@ package civogo
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -24,6 +27,14 @@ type Volume struct {
 	CreatedAt     time.Time `json:"created_at"`
 }
 
+const (
+	// VolumeStatusAvailable is the Status of a volume that isn't attached to anything
+	VolumeStatusAvailable = "available"
+
+	// VolumeStatusAttached is the Status of a volume attached to an instance
+	VolumeStatusAttached = "attached"
+)
+
 // VolumeResult is the response from one of our simple API calls
 type VolumeResult struct {
 	ID     string `json:"id"`
@@ -67,6 +78,50 @@ func (c *Client) ListVolumes() ([]Volume, error) {
 	return volumes, nil
 }
 
+// VolumeFilter narrows the volumes ListVolumesFiltered returns, zero fields aren't checked.
+// Region is passed to the API, which lists that region's volumes instead of the client's.
+// InstanceID and Attached are checked here, as the API can't filter on them
+type VolumeFilter struct {
+	// InstanceID only keeps the volumes attached to that instance
+	InstanceID string
+	// Attached only keeps attached volumes if true, or detached ones if false
+	Attached *bool
+	// Region lists the volumes in that region rather than the client's
+	Region string
+}
+
+// ListVolumesFiltered returns the volumes that match opts, e.g. only the detached ones when
+// looking for volumes to clean up
+func (c *Client) ListVolumesFiltered(opts VolumeFilter) ([]Volume, error) {
+	requestURL := "/v2/volumes"
+	if opts.Region != "" {
+		requestURL += "?" + url.Values{"region": {opts.Region}}.Encode()
+	}
+
+	resp, err := c.SendGetRequest(requestURL)
+	if err != nil {
+		return nil, decodeError(err)
+	}
+
+	var volumes = make([]Volume, 0)
+	if err := json.NewDecoder(bytes.NewReader(resp)).Decode(&volumes); err != nil {
+		return nil, err
+	}
+
+	filtered := make([]Volume, 0, len(volumes))
+	for _, volume := range volumes {
+		if opts.InstanceID != "" && volume.InstanceID != opts.InstanceID {
+			continue
+		}
+		if opts.Attached != nil && (volume.InstanceID != "") != *opts.Attached {
+			continue
+		}
+		filtered = append(filtered, volume)
+	}
+
+	return filtered, nil
+}
+
 // ListVolumesForCluster returns all volumes for a cluster
 func (c *Client) ListVolumesForCluster(clusterID string) ([]Volume, error) {
 	cluster, err := c.FindKubernetesCluster(clusterID)
@@ -129,7 +184,12 @@ func findString(slice []string, val string) bool {
 
 // GetVolume finds a volume by the full ID
 func (c *Client) GetVolume(id string) (*Volume, error) {
-	resp, err := c.SendGetRequest(fmt.Sprintf("/v2/volumes/%s", id))
+	return c.GetVolumeWithContext(context.Background(), id)
+}
+
+// GetVolumeWithContext is GetVolume bound to ctx
+func (c *Client) GetVolumeWithContext(ctx context.Context, id string) (*Volume, error) {
+	resp, err := c.SendGetRequestWithContext(ctx, fmt.Sprintf("/v2/volumes/%s", id))
 	if err != nil {
 		return nil, decodeError(err)
 	}
@@ -142,6 +202,40 @@ func (c *Client) GetVolume(id string) (*Volume, error) {
 	return &volume, nil
 }
 
+// WaitForVolumeStatus polls the volume until its Status is status (e.g. "attached"), returning
+// the volume once it is, so a script doesn't use a device before it's there. It gives up after
+// timeout, or as soon as the volume reaches a failed state. pollInterval optionally overrides
+// the default of 5 seconds between checks
+func (c *Client) WaitForVolumeStatus(id, status string, timeout time.Duration, pollInterval ...time.Duration) (*Volume, error) {
+	return c.WaitForVolumeStatusWithContext(context.Background(), id, status, timeout, pollInterval...)
+}
+
+// WaitForVolumeStatusWithContext is WaitForVolumeStatus bound to ctx
+func (c *Client) WaitForVolumeStatusWithContext(ctx context.Context, id, status string, timeout time.Duration, pollInterval ...time.Duration) (*Volume, error) {
+	var volume *Volume
+	err := pollUntil(ctx, timeout, pollIntervalOrDefault(pollInterval), fmt.Sprintf("volume %s to be %s", id, status), func(ctx context.Context) (bool, error) {
+		var err error
+		volume, err = c.GetVolumeWithContext(ctx, id)
+		if err != nil {
+			return false, err
+		}
+
+		if volume.Status != status && (strings.EqualFold(volume.Status, "failed") || strings.EqualFold(volume.Status, "error")) {
+			err := fmt.Errorf("volume %s is in the %s state", id, volume.Status)
+			return false, VolumeFailedError.wrap(err)
+		}
+		return volume.Status == status, nil
+	})
+	if err != nil {
+		if errors.Is(err, VolumeFailedError) {
+			return volume, err
+		}
+		return nil, err
+	}
+
+	return volume, nil
+}
+
 // FindVolume finds a volume by either part of the ID or part of the name
 func (c *Client) FindVolume(search string) (*Volume, error) {
 	volumes, err := c.ListVolumes()
@@ -219,6 +313,65 @@ func (c *Client) AttachVolume(id string, v VolumeAttachConfig) (*SimpleResponse,
 	return response, err
 }
 
+// VolumeAttachResult is the outcome of attaching one volume as part of AttachVolumes
+type VolumeAttachResult struct {
+	VolumeID string
+	Attached bool
+	Err      error
+}
+
+// AttachVolumes attaches each of the volumes to the instance in turn, waiting up to timeout for
+// each to report it's attached before moving on, and returns a result per volume in the same
+// order. Volumes already attached to another instance are skipped with a
+// VolumeAttachedElsewhereError. pollInterval optionally overrides the default of 5 seconds
+// between checks
+func (c *Client) AttachVolumes(instanceID string, volumeIDs []string, timeout time.Duration, pollInterval ...time.Duration) ([]VolumeAttachResult, error) {
+	return c.AttachVolumesWithContext(context.Background(), instanceID, volumeIDs, timeout, pollInterval...)
+}
+
+// AttachVolumesWithContext is AttachVolumes bound to ctx
+func (c *Client) AttachVolumesWithContext(ctx context.Context, instanceID string, volumeIDs []string, timeout time.Duration, pollInterval ...time.Duration) ([]VolumeAttachResult, error) {
+	if _, err := c.GetInstanceWithContext(ctx, instanceID); err != nil {
+		return nil, err
+	}
+
+	results := make([]VolumeAttachResult, len(volumeIDs))
+	for i, volumeID := range volumeIDs {
+		results[i] = VolumeAttachResult{VolumeID: volumeID}
+		results[i].Err = c.attachVolumeAndWait(ctx, instanceID, volumeID, timeout, pollIntervalOrDefault(pollInterval))
+		results[i].Attached = results[i].Err == nil
+	}
+
+	return results, nil
+}
+
+func (c *Client) attachVolumeAndWait(ctx context.Context, instanceID, volumeID string, timeout, pollInterval time.Duration) error {
+	volume, err := c.GetVolumeWithContext(ctx, volumeID)
+	if err != nil {
+		return err
+	}
+
+	if volume.InstanceID == instanceID {
+		return nil
+	}
+	if volume.InstanceID != "" {
+		err := fmt.Errorf("volume %s is already attached to instance %s", volumeID, volume.InstanceID)
+		return VolumeAttachedElsewhereError.wrap(err)
+	}
+
+	if _, err := c.AttachVolume(volumeID, VolumeAttachConfig{InstanceID: instanceID, Region: c.Region}); err != nil {
+		return err
+	}
+
+	return pollUntil(ctx, timeout, pollInterval, fmt.Sprintf("volume %s to attach to instance %s", volumeID, instanceID), func(ctx context.Context) (bool, error) {
+		volume, err := c.GetVolumeWithContext(ctx, volumeID)
+		if err != nil {
+			return false, err
+		}
+		return volume.InstanceID == instanceID && volume.Status == VolumeStatusAttached, nil
+	})
+}
+
 // DetachVolume attach volume from any instances
 // https://www.civo.com/api/volumes#attach-a-volume-to-an-instance
 func (c *Client) DetachVolume(id string) (*SimpleResponse, error) {
@@ -287,6 +440,85 @@ func (c *Client) CreateVolumeSnapshot(volumeID string, config *VolumeSnapshotCon
 	return result, nil
 }
 
+// SnapshotVolume takes a snapshot of a volume in the client's region, named name
+func (c *Client) SnapshotVolume(volumeID string, name string) (*VolumeSnapshot, error) {
+	if len(volumeID) == 0 {
+		err := fmt.Errorf("the volume ID is empty")
+		return nil, IDisEmptyError.wrap(err)
+	}
+
+	return c.CreateVolumeSnapshot(volumeID, &VolumeSnapshotConfig{Name: name, Region: c.Region})
+}
+
+// CloneVolume copies a volume into a new volume called newName, with the same size, type and
+// network. Live-clone isn't supported: the source volume must be detached and available, otherwise
+// a VolumeNotAvailableError is returned. The clone is made by snapshotting the source, waiting up
+// to timeout for the snapshot to be ready and creating the new volume from it; the snapshot (named
+// after the new volume) is kept and can be removed with DeleteVolumeSnapshot once it's no longer
+// needed. pollInterval optionally overrides the default of 5 seconds between checks
+func (c *Client) CloneVolume(volumeID string, newName string, timeout time.Duration, pollInterval ...time.Duration) (*Volume, error) {
+	return c.CloneVolumeWithContext(context.Background(), volumeID, newName, timeout, pollInterval...)
+}
+
+// CloneVolumeWithContext is CloneVolume bound to ctx
+func (c *Client) CloneVolumeWithContext(ctx context.Context, volumeID string, newName string, timeout time.Duration, pollInterval ...time.Duration) (*Volume, error) {
+	source, err := c.GetVolumeWithContext(ctx, volumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.InstanceID != "" || source.Status != VolumeStatusAvailable {
+		err := fmt.Errorf("volume %s must be detached and available to clone, it is %s", volumeID, source.Status)
+		return nil, VolumeNotAvailableError.wrap(err)
+	}
+
+	snapshot, err := c.SnapshotVolume(volumeID, newName)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.waitForVolumeSnapshotReady(ctx, volumeID, snapshot.SnapshotID, timeout, pollIntervalOrDefault(pollInterval))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.NewVolume(&VolumeConfig{
+		Name:          newName,
+		NetworkID:     source.NetworkID,
+		Region:        c.Region,
+		SizeGigabytes: source.SizeGigabytes,
+		VolumeType:    source.VolumeType,
+		SnapshotID:    snapshot.SnapshotID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetVolumeWithContext(ctx, result.ID)
+}
+
+// waitForVolumeSnapshotReady polls the snapshot of volumeID until it's ready, returning it, or a
+// VolumeSnapshotFailedError if it failed
+func (c *Client) waitForVolumeSnapshotReady(ctx context.Context, volumeID, snapshotID string, timeout, pollInterval time.Duration) (*VolumeSnapshot, error) {
+	var snapshot *VolumeSnapshot
+	err := pollUntil(ctx, timeout, pollInterval, fmt.Sprintf("snapshot %s of volume %s to be ready", snapshotID, volumeID), func(ctx context.Context) (bool, error) {
+		var err error
+		snapshot, err = c.GetVolumeSnapshotByVolumeID(volumeID, snapshotID)
+		if err != nil {
+			return false, err
+		}
+		if strings.EqualFold(snapshot.State, VolumeSnapshotStateFailed) {
+			err := fmt.Errorf("snapshot %s of volume %s failed", snapshotID, volumeID)
+			return false, VolumeSnapshotFailedError.wrap(err)
+		}
+		return strings.EqualFold(snapshot.State, VolumeSnapshotStateReady), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
 // DeleteVolumeAndAllSnapshot deletes a volume and all its snapshots
 func (c *Client) DeleteVolumeAndAllSnapshot(volumeID string) (*SimpleResponse, error) {
 	resp, err := c.SendDeleteRequest(fmt.Sprintf("/v2/volumes/%s?delete_snapshot=true", volumeID))
@@ -19,6 +19,14 @@ type VolumeSnapshot struct {
 	CreationTime        string `json:"creation_time,omitempty"`
 }
 
+const (
+	// VolumeSnapshotStateReady is the State of a snapshot that can be restored from
+	VolumeSnapshotStateReady = "ready"
+
+	// VolumeSnapshotStateFailed is the State of a snapshot that couldn't be taken
+	VolumeSnapshotStateFailed = "failed"
+)
+
 // VolumeSnapshotConfig is the configuration for creating a new VolumeSnapshot
 type VolumeSnapshotConfig struct {
 	Name        string `json:"name"`
@@ -1,8 +1,14 @@
 package civogo
 
 import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestListVolumes(t *testing.T) {
@@ -30,6 +36,49 @@ func TestListVolumes(t *testing.T) {
 	}
 }
 
+func TestListVolumesFiltered(t *testing.T) {
+	var gotRegion string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		gotRegion = req.URL.Query().Get("region")
+		rw.Write([]byte(`[{"id": "vol-1", "instance_id": "12345"}, {"id": "vol-2", "instance_id": "67890"}, {"id": "vol-3"}]`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	ids := func(volumes []Volume) []string {
+		result := []string{}
+		for _, v := range volumes {
+			result = append(result, v.ID)
+		}
+		return result
+	}
+
+	attached, detached := true, false
+	tests := []struct {
+		opts     VolumeFilter
+		region   string
+		expected []string
+	}{
+		{VolumeFilter{}, "TEST", []string{"vol-1", "vol-2", "vol-3"}},
+		{VolumeFilter{Attached: &detached}, "TEST", []string{"vol-3"}},
+		{VolumeFilter{Attached: &attached}, "TEST", []string{"vol-1", "vol-2"}},
+		{VolumeFilter{InstanceID: "67890", Region: "LON1"}, "LON1", []string{"vol-2"}},
+	}
+	for _, test := range tests {
+		got, err := client.ListVolumesFiltered(test.opts)
+		if err != nil {
+			t.Errorf("Request returned an error: %s", err)
+			return
+		}
+		if !reflect.DeepEqual(ids(got), test.expected) {
+			t.Errorf("Expected %v, got %v", test.expected, ids(got))
+		}
+		if gotRegion != test.region {
+			t.Errorf("Expected region %s, got %s", test.region, gotRegion)
+		}
+	}
+}
+
 func TestListVolumesForCluster(t *testing.T) {
 	client, server, _ := NewClientForTesting(map[string]string{
 
@@ -393,3 +442,170 @@ func TestDeleteVolumeAndAllSnapshot(t *testing.T) {
 		t.Errorf("Expected %+v, got %+v", expected, got)
 	}
 }
+
+func TestAttachVolumesToInstance(t *testing.T) {
+	var mu sync.Mutex
+	attached := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch req.URL.Path {
+		case "/v2/instances/instance-1":
+			rw.Write([]byte(`{"id":"instance-1"}`))
+		case "/v2/volumes/vol-1/attach", "/v2/volumes/vol-2/attach":
+			attached[req.URL.Path[len("/v2/volumes/"):len(req.URL.Path)-len("/attach")]] = true
+			rw.Write([]byte(`{"result":"success"}`))
+		case "/v2/volumes/vol-1", "/v2/volumes/vol-2":
+			id := req.URL.Path[len("/v2/volumes/"):]
+			if attached[id] {
+				rw.Write([]byte(`{"id":"` + id + `","instance_id":"instance-1","status":"attached"}`))
+				return
+			}
+			rw.Write([]byte(`{"id":"` + id + `","status":"available"}`))
+		case "/v2/volumes/vol-3":
+			rw.Write([]byte(`{"id":"vol-3","instance_id":"instance-2","status":"attached"}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			rw.Write([]byte(`{"code":"database_volume_not_found","reason":"not found"}`))
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.AttachVolumes("instance-1", []string{"vol-1", "vol-3", "vol-2"}, time.Second, time.Millisecond)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if len(got) != 3 {
+		t.Errorf("Expected %d, got %d", 3, len(got))
+		return
+	}
+	if !got[0].Attached || got[0].Err != nil || !got[2].Attached || got[2].Err != nil {
+		t.Errorf("Expected vol-1 and vol-2 to be attached, got %+v", got)
+	}
+	if got[1].Attached || !errors.Is(got[1].Err, VolumeAttachedElsewhereError) {
+		t.Errorf("Expected %v for vol-3, got %+v", VolumeAttachedElsewhereError, got[1])
+	}
+}
+
+func TestSnapshotVolume(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/volumes/12345/snapshots": `{"name": "nightly", "snapshot_id": "snap-1", "volume_id": "12345", "state": "Pending"}`,
+	})
+	defer server.Close()
+
+	got, err := client.SnapshotVolume("12345", "nightly")
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.SnapshotID != "snap-1" {
+		t.Errorf("Expected %s, got %s", "snap-1", got.SnapshotID)
+	}
+
+	_, err = client.SnapshotVolume("", "nightly")
+	if !errors.Is(err, IDisEmptyError) {
+		t.Errorf("Expected %v, got %v", IDisEmptyError, err)
+	}
+}
+
+func TestCloneVolume(t *testing.T) {
+	var mu sync.Mutex
+	checks := 0
+	var created VolumeConfig
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case req.URL.Path == "/v2/volumes/vol-1":
+			rw.Write([]byte(`{"id":"vol-1","network_id":"net-1","status":"available","volume_type":"ms-xfs-2-replicas","size_gb":20}`))
+		case req.URL.Path == "/v2/volumes/vol-2":
+			rw.Write([]byte(`{"id":"vol-2","instance_id":"instance-1","status":"attached"}`))
+		case req.Method == "POST" && req.URL.Path == "/v2/volumes/vol-1/snapshots":
+			rw.Write([]byte(`{"name":"copy","snapshot_id":"snap-1","volume_id":"vol-1","state":"Pending"}`))
+		case req.URL.Path == "/v2/volumes/vol-1/snapshots/snap-1":
+			checks++
+			if checks < 2 {
+				rw.Write([]byte(`{"snapshot_id":"snap-1","state":"Pending"}`))
+				return
+			}
+			rw.Write([]byte(`{"snapshot_id":"snap-1","state":"Ready"}`))
+		case req.Method == "POST" && req.URL.Path == "/v2/volumes":
+			json.NewDecoder(req.Body).Decode(&created)
+			rw.Write([]byte(`{"id":"vol-3","name":"copy","result":"success"}`))
+		case req.URL.Path == "/v2/volumes/vol-3":
+			rw.Write([]byte(`{"id":"vol-3","name":"copy","status":"available","size_gb":20}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			rw.Write([]byte(`{"code":"database_volume_not_found","reason":"not found"}`))
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.CloneVolume("vol-1", "copy", time.Second, time.Millisecond)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.ID != "vol-3" {
+		t.Errorf("Expected %s, got %s", "vol-3", got.ID)
+	}
+
+	expected := VolumeConfig{Name: "copy", NetworkID: "net-1", Region: "TEST", SizeGigabytes: 20, VolumeType: "ms-xfs-2-replicas", SnapshotID: "snap-1"}
+	if !reflect.DeepEqual(created, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, created)
+	}
+
+	_, err = client.CloneVolume("vol-2", "copy", time.Second, time.Millisecond)
+	if !errors.Is(err, VolumeNotAvailableError) {
+		t.Errorf("Expected %v, got %v", VolumeNotAvailableError, err)
+	}
+}
+
+func TestWaitForVolumeStatus(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+		status := "attaching"
+		if calls > 2 {
+			status = VolumeStatusAttached
+		}
+		rw.Write([]byte(`{"id":"12345","name":"my-volume","instance_id":"67890","status":"` + status + `"}`))
+	}))
+	defer server.Close()
+	client, _ := NewClientForTestingWithServer(server)
+
+	got, err := client.WaitForVolumeStatus("12345", VolumeStatusAttached, time.Second, time.Millisecond)
+	if err != nil {
+		t.Errorf("Request returned an error: %s", err)
+		return
+	}
+	if got.Status != VolumeStatusAttached || got.InstanceID != "67890" {
+		t.Errorf("Expected an attached my-volume, got %+v", got)
+	}
+	if calls != 3 {
+		t.Errorf("Expected %d, got %d", 3, calls)
+	}
+}
+
+func TestWaitForVolumeStatusFailed(t *testing.T) {
+	client, server, _ := NewClientForTesting(map[string]string{
+		"/v2/volumes/12345": `{"id":"12345","status":"error"}`,
+	})
+	defer server.Close()
+
+	got, err := client.WaitForVolumeStatus("12345", VolumeStatusAttached, time.Second, time.Millisecond)
+	if !errors.Is(err, VolumeFailedError) {
+		t.Errorf("Expected %v, got %v", VolumeFailedError, err)
+	}
+	if got == nil || got.Status != "error" {
+		t.Errorf("Expected the failed volume to be returned, got %+v", got)
+	}
+
+	_, err = client.WaitForVolumeStatus("12345", VolumeStatusAvailable, 20*time.Millisecond, time.Millisecond)
+	if !errors.Is(err, VolumeFailedError) {
+		t.Errorf("Expected %v, got %v", VolumeFailedError, err)
+	}
+}
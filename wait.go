@@ -0,0 +1,66 @@
+package civogo
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// defaultPollInterval is how often the WaitFor helpers check on a resource by default
+const defaultPollInterval = 5 * time.Second
+
+// pollIntervalOrDefault returns the first of intervals if one was given, otherwise the default
+func pollIntervalOrDefault(intervals []time.Duration) time.Duration {
+	if len(intervals) > 0 && intervals[0] > 0 {
+		return intervals[0]
+	}
+	return defaultPollInterval
+}
+
+// pollUntil calls check every interval until it reports done, returns an error, or timeout
+// elapses. what describes the wait for the TimeoutError message, e.g. "instance 123 to be ACTIVE"
+func pollUntil(ctx context.Context, timeout, interval time.Duration, what string, check func(ctx context.Context) (bool, error)) error {
+	return pollWithBackoff(ctx, timeout, interval, interval, what, check)
+}
+
+// pollWithBackoff is pollUntil, doubling the time between checks after each one, from interval up
+// to maxInterval
+func pollWithBackoff(ctx context.Context, timeout, interval, maxInterval time.Duration, what string, check func(ctx context.Context) (bool, error)) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		done, err := check(ctx)
+		if err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				err := fmt.Errorf("timed out after %s waiting for %s", timeout, what)
+				return TimeoutError.wrap(err)
+			}
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				err := fmt.Errorf("timed out after %s waiting for %s", timeout, what)
+				return TimeoutError.wrap(err)
+			}
+			return RequestCanceledError.wrap(ctx.Err())
+		case <-timer.C:
+		}
+
+		if interval < maxInterval {
+			interval *= 2
+			if interval > maxInterval {
+				interval = maxInterval
+			}
+		}
+		timer.Reset(interval)
+	}
+}
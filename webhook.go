@@ -2,9 +2,14 @@ package civogo
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 )
 
 // Webhook is a representation of a saved webhook callback from changes in Civo
@@ -25,6 +30,16 @@ type WebhookConfig struct {
 	Secret string   `json:"secret"`
 }
 
+// WebhookEvent is a single event delivered to a webhook's URL. Payload is the resource the event
+// is about, left as raw JSON since its shape depends on the event
+type WebhookEvent struct {
+	ID        string          `json:"id"`
+	Event     string          `json:"event"`
+	AccountID string          `json:"account_id,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+}
+
 // CreateWebhook creates a new webhook
 func (c *Client) CreateWebhook(r *WebhookConfig) (*Webhook, error) {
 	body, err := c.SendPostRequest("/v2/webhooks", r)
@@ -113,3 +128,50 @@ func (c *Client) DeleteWebhook(id string) (*SimpleResponse, error) {
 
 	return c.DecodeSimpleResponse(resp)
 }
+
+// VerifyWebhookSignature reports whether signatureHeader is the HMAC-SHA256 of payload keyed with
+// the webhook's secret. The signature may be hex or base64 encoded, optionally with a "sha256="
+// prefix, and is compared in constant time. payload must be the request body exactly as received
+func VerifyWebhookSignature(payload []byte, signatureHeader, secret string) (bool, error) {
+	if secret == "" {
+		err := fmt.Errorf("the webhook secret is empty")
+		return false, WebhookSignatureInvalidError.wrap(err)
+	}
+
+	encoded := strings.TrimSpace(signatureHeader)
+	encoded = strings.TrimPrefix(encoded, "sha256=")
+	if encoded == "" {
+		err := fmt.Errorf("the webhook signature is empty")
+		return false, WebhookSignatureInvalidError.wrap(err)
+	}
+
+	signature, err := hex.DecodeString(encoded)
+	if err != nil {
+		signature, err = base64.StdEncoding.DecodeString(encoded)
+	}
+	if err != nil || len(signature) != sha256.Size {
+		err := fmt.Errorf("the webhook signature %q is not a hex or base64 encoded SHA256 HMAC", signatureHeader)
+		return false, WebhookSignatureInvalidError.wrap(err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return hmac.Equal(signature, mac.Sum(nil)), nil
+}
+
+// ParseWebhookEvent decodes a webhook request body into a WebhookEvent. Verify the body with
+// VerifyWebhookSignature before trusting the event
+func ParseWebhookEvent(payload []byte) (*WebhookEvent, error) {
+	event := &WebhookEvent{}
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, WebhookEventInvalidError.wrap(err)
+	}
+
+	if event.Event == "" {
+		err := fmt.Errorf("the webhook event has no event name")
+		return nil, WebhookEventInvalidError.wrap(err)
+	}
+
+	return event, nil
+}
@@ -1,6 +1,11 @@
 package civogo
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -190,3 +195,56 @@ func TestDeleteWebhook(t *testing.T) {
 		t.Errorf("Expected %+v, got %+v", expected, got)
 	}
 }
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	payload := []byte(`{"id":"12345","event":"instance.created","payload":{"id":"67890"}}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(payload)
+	signature := mac.Sum(nil)
+
+	tests := []struct {
+		header   string
+		secret   string
+		expected bool
+	}{
+		{hex.EncodeToString(signature), "secret", true},
+		{"sha256=" + hex.EncodeToString(signature), "secret", true},
+		{base64.StdEncoding.EncodeToString(signature), "secret", true},
+		{hex.EncodeToString(signature), "wrong-secret", false},
+	}
+	for _, test := range tests {
+		got, err := VerifyWebhookSignature(payload, test.header, test.secret)
+		if err != nil {
+			t.Errorf("Verifying %s returned an error: %s", test.header, err)
+			continue
+		}
+		if got != test.expected {
+			t.Errorf("Expected %t for %s with %s, got %t", test.expected, test.header, test.secret, got)
+		}
+	}
+
+	if _, err := VerifyWebhookSignature(payload, "not-a-signature", "secret"); !errors.Is(err, WebhookSignatureInvalidError) {
+		t.Errorf("Expected %v, got %v", WebhookSignatureInvalidError, err)
+	}
+	if _, err := VerifyWebhookSignature(payload, hex.EncodeToString(signature), ""); !errors.Is(err, WebhookSignatureInvalidError) {
+		t.Errorf("Expected %v, got %v", WebhookSignatureInvalidError, err)
+	}
+}
+
+func TestParseWebhookEvent(t *testing.T) {
+	got, err := ParseWebhookEvent([]byte(`{"id":"12345","event":"instance.created","payload":{"id":"67890"}}`))
+	if err != nil {
+		t.Errorf("Parsing returned an error: %s", err)
+		return
+	}
+	if got.ID != "12345" || got.Event != "instance.created" || string(got.Payload) != `{"id":"67890"}` {
+		t.Errorf("Expected an instance.created event, got %+v", got)
+	}
+
+	if _, err := ParseWebhookEvent([]byte(`{"id":`)); !errors.Is(err, WebhookEventInvalidError) {
+		t.Errorf("Expected %v, got %v", WebhookEventInvalidError, err)
+	}
+	if _, err := ParseWebhookEvent([]byte(`{"id":"12345"}`)); !errors.Is(err, WebhookEventInvalidError) {
+		t.Errorf("Expected %v, got %v", WebhookEventInvalidError, err)
+	}
+}